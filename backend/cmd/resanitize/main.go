@@ -0,0 +1,74 @@
+// Command resanitize runs the HTML sanitizer over content stored before it existed.
+//
+//	go run ./cmd/resanitize            # rewrite rows whose sanitized form differs
+//	go run ./cmd/resanitize -dry-run   # only report what would change
+package main
+
+import (
+	"backend/database"
+	"backend/security"
+	"flag"
+	"fmt"
+)
+
+// column is a stored text field and how it is sanitized
+type column struct {
+	Table    string
+	Column   string
+	RichText bool // Quill HTML; otherwise plain text
+}
+
+var columns = []column{
+	{Table: "poems", Column: "content", RichText: true},
+	{Table: "homepages", Column: "title", RichText: true},
+	{Table: "homepages", Column: "subtitle", RichText: true},
+	{Table: "homepages", Column: "content", RichText: true},
+	{Table: "mihrimah_cards", Column: "title", RichText: true},
+	{Table: "mihrimah_cards", Column: "content", RichText: true},
+	{Table: "authors", Column: "bio", RichText: true},
+	{Table: "comments", Column: "title"},
+	{Table: "comments", Column: "content"},
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report changes without writing them")
+	flag.Parse()
+
+	database.ConnectDb()
+	sanitizer := security.NewSanitizer()
+
+	for _, col := range columns {
+		var rows []struct {
+			ID    uint
+			Value string
+		}
+		if err := database.DB.Table(col.Table).
+			Select("id, " + col.Column + " AS value").
+			Where(col.Column + " IS NOT NULL AND " + col.Column + " <> ''").
+			Order("id").
+			Scan(&rows).Error; err != nil {
+			fmt.Printf("[Resanitize] %s.%s: %v\n", col.Table, col.Column, err)
+			continue
+		}
+
+		changed := 0
+		for _, row := range rows {
+			clean := sanitizer.SanitizePlainText(row.Value)
+			if col.RichText {
+				clean = sanitizer.SanitizeRichText(row.Value)
+			}
+			if clean == row.Value {
+				continue
+			}
+			changed++
+			if *dryRun {
+				fmt.Printf("[Resanitize] would update %s.%s id=%d\n", col.Table, col.Column, row.ID)
+				continue
+			}
+			if err := database.DB.Table(col.Table).Where("id = ?", row.ID).Update(col.Column, clean).Error; err != nil {
+				fmt.Printf("[Resanitize] %s.%s id=%d: %v\n", col.Table, col.Column, row.ID, err)
+			}
+		}
+		fmt.Printf("[Resanitize] %s.%s: %d of %d rows changed\n", col.Table, col.Column, changed, len(rows))
+	}
+}
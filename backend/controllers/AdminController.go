@@ -868,31 +868,28 @@ func GetUserLikedPoems(c *fiber.Ctx) error {
 		query = query.Where("poems.community = ?", 2)
 	}
 
+	// sort=recent lists the most recently liked poems first
+	if order := relationSortOrder(c.Query("sort"), "admin_liked_poems"); order != "" {
+		query = query.Order(order)
+	}
+
 	if err := query.Pluck("poem_id", &poemIDs).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Şiirler yüklenirken hata oluştu",
 		})
 	}
 
-	// Now load the full poems with author data
-	var likedPoems []models.Poem
-	if len(poemIDs) > 0 {
-		if err := database.DB.
-			Preload("AuthorData").
-			Where("id IN ?", poemIDs).
-			Find(&likedPoems).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"message": "Şiirler yüklenirken hata oluştu",
-			})
-		}
-	}
+	// Now load the full poems with author data, keeping the relation order
+	likedPoems := loadPoemsInOrder(poemIDs)
 
 	return c.JSON(fiber.Map{
 		"poems": likedPoems,
 	})
 }
 
-// GetUserReadBooks returns user's read books (lazy loading)
+// GetUserReadBooks returns the books on one of a user's shelves (lazy loading).
+// ?status= picks a built-in shelf (default finished, "all" for every status), ?shelf= a custom
+// shelf by slug; ?search= and ?sort=recent|oldest|title|author|progress|started|finished refine it.
 func GetUserReadBooks(c *fiber.Ctx) error {
 	username := c.Params("username")
 
@@ -927,14 +924,33 @@ func GetUserReadBooks(c *fiber.Ctx) error {
 		viewerRoleID = viewer.RoleID
 	}
 
-	// Load read books with filtering based on role
-	// First, get the book IDs from the junction table
-	var bookIDs []uint
-	query := database.DB.
-		Table("user_books_read").
-		Select("user_books_read.book_id").
-		Joins("JOIN books ON user_books_read.book_id = books.id").
-		Where("user_books_read.admin_id = ?", admin.ID)
+	query := database.DB.Model(&models.Book{}).
+		Select("books.id").
+		Joins("LEFT JOIN book_progresses ON book_progresses.book_id = books.id AND book_progresses.admin_id = ?", admin.ID).
+		Where("books.is_deleted = ?", false)
+
+	// A custom shelf, or one or all of the built-in shelves
+	addedColumn := "book_progresses.status_changed_at"
+	status := c.Query("status", models.ShelfFinished)
+	if slug := c.Query("shelf"); slug != "" {
+		var shelf models.Shelf
+		if err := database.DB.Where("admin_id = ? AND slug = ?", admin.ID, slug).First(&shelf).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Raf bulunamadı",
+			})
+		}
+		query = query.Joins("JOIN shelf_books ON shelf_books.book_id = books.id AND shelf_books.shelf_id = ?", shelf.ID)
+		addedColumn = "shelf_books.created_at"
+		status = ""
+	} else if status == "all" {
+		query = query.Where("book_progresses.id IS NOT NULL")
+	} else if isShelfStatus(status) {
+		query = query.Where("book_progresses.status = ?", status)
+	} else {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz raf",
+		})
+	}
 
 	// Filter by community based on viewer's role
 	// role_id 0 (not logged in) or 3 (Misafir): only show community=2
@@ -943,27 +959,88 @@ func GetUserReadBooks(c *fiber.Ctx) error {
 		query = query.Where("books.community = ?", 2)
 	}
 
-	if err := query.Pluck("book_id", &bookIDs).Error; err != nil {
+	if search := c.Query("search"); search != "" {
+		searchPattern := "%" + search + "%"
+		query = query.Where("books.name ILIKE ? OR books.author ILIKE ?", searchPattern, searchPattern)
+	}
+	query = applyBookMetadataFilters(query, c)
+
+	var bookIDs []uint
+	if err := query.Order(shelvedBookOrder(c.Query("sort"), addedColumn)).Pluck("books.id", &bookIDs).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Kitaplar yüklenirken hata oluştu",
 		})
 	}
 
-	// Now load the full books with author data
-	var readBooks []models.Book
+	// Now load the full books with author data and the owner's reading state
+	var books []models.Book
+	var progress []models.BookProgress
 	if len(bookIDs) > 0 {
 		if err := database.DB.
 			Preload("AuthorData").
-			Where("id IN ? AND is_deleted = ?", bookIDs, false).
-			Find(&readBooks).Error; err != nil {
+			Preload("Genres").
+			Preload("Series").
+			Where("id IN ?", bookIDs).
+			Find(&books).Error; err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"message": "Kitaplar yüklenirken hata oluştu",
 			})
 		}
+		database.DB.Where("admin_id = ? AND book_id IN ?", admin.ID, bookIDs).Find(&progress)
+	}
+
+	// Keep the shelf order
+	booksByID := make(map[uint]models.Book, len(books))
+	for _, book := range books {
+		booksByID[book.ID] = book
+	}
+	progressByBook := make(map[uint]*models.BookProgress, len(progress))
+	for i := range progress {
+		progressByBook[progress[i].BookID] = &progress[i]
+	}
+	shelvedBooks := make([]ShelvedBook, 0, len(books))
+	for _, bookID := range bookIDs {
+		if book, ok := booksByID[bookID]; ok {
+			shelvedBooks = append(shelvedBooks, ShelvedBook{Book: book, Progress: progressByBook[bookID]})
+		}
 	}
 
 	return c.JSON(fiber.Map{
-		"books": readBooks,
+		"books":  shelvedBooks,
+		"status": status,
+		"shelf":  c.Query("shelf"),
+	})
+}
+
+// GetUserShelves returns a user's built-in and custom shelves with book counts
+func GetUserShelves(c *fiber.Ctx) error {
+	username := c.Params("username")
+	viewerID := GetUserId(c)
+
+	var admin models.Admin
+	if err := database.DB.Where("username = ?", username).First(&admin).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kullanıcı bulunamadı",
+		})
+	}
+
+	if viewerID != admin.ID && admin.IsPrivate && !AreFriends(viewerID, admin.ID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu içeriği görüntülemek için yetkiniz yok",
+		})
+	}
+
+	var viewer models.Admin
+	viewerRoleID := uint(0)
+	if viewerID > 0 {
+		database.DB.Select("role_id").Where("id = ?", viewerID).First(&viewer)
+		viewerRoleID = viewer.RoleID
+	}
+
+	statuses, shelves := shelfSummaries(admin.ID, viewerRoleID)
+	return c.JSON(fiber.Map{
+		"statuses": statuses,
+		"shelves":  shelves,
 	})
 }
 
@@ -1013,24 +1090,19 @@ func GetUserBookmarkedPoems(c *fiber.Ctx) error {
 		query = query.Where("poems.community = ?", 2)
 	}
 
+	// sort=recent lists the most recently bookmarked poems first
+	if order := relationSortOrder(c.Query("sort"), "admin_bookmark_poems"); order != "" {
+		query = query.Order(order)
+	}
+
 	if err := query.Pluck("poem_id", &poemIDs).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Şiirler yüklenirken hata oluştu",
 		})
 	}
 
-	// Now load the full poems with author data
-	var bookmarkedPoems []models.Poem
-	if len(poemIDs) > 0 {
-		if err := database.DB.
-			Preload("AuthorData").
-			Where("id IN ?", poemIDs).
-			Find(&bookmarkedPoems).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"message": "Şiirler yüklenirken hata oluştu",
-			})
-		}
-	}
+	// Now load the full poems with author data, keeping the relation order
+	bookmarkedPoems := loadPoemsInOrder(poemIDs)
 
 	return c.JSON(fiber.Map{
 		"poems": bookmarkedPoems,
@@ -1064,6 +1136,7 @@ func GetUserComments(c *fiber.Ctx) error {
 	if err := database.DB.
 		Where("admin_id = ? AND is_deleted = ?", admin.ID, false).
 		Preload("Book").
+		Preload("Poem").
 		Preload("Admin", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "username", "email", "role_id", "profile_image")
 		}).
@@ -1078,3 +1151,14 @@ func GetUserComments(c *fiber.Ctx) error {
 		"comments": comments,
 	})
 }
+
+// relationSortOrder maps a profile listing sort option to an ORDER BY on the join table
+func relationSortOrder(sort string, table string) string {
+	switch sort {
+	case "recent":
+		return table + ".created_at DESC"
+	case "oldest":
+		return table + ".created_at ASC"
+	}
+	return ""
+}
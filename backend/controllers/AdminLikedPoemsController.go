@@ -135,6 +135,9 @@ func GetLikedPoemsPaginated(c *fiber.Ctx) error {
 		Limit(params.Limit).
 		Scan(&poems)
 
+	roleID, _ := helpers.GetUserRole(c)
+	attachPoemListData(poems, userID, roleID)
+
 	// Create paginated response
 	response := helpers.CreatePaginationResponse(poems, total, params.Offset, params.Limit)
 
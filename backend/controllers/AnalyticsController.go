@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxAnalyticsDays = 365
+
+// ReadingStats are view and like aggregates over an analytics window
+type ReadingStats struct {
+	Views           int64   `json:"views"`
+	UniqueReaders   int64   `json:"unique_readers"`
+	Likes           int64   `json:"likes"`
+	LikeToViewRatio float64 `json:"like_to_view_ratio"`
+}
+
+// DailyReadingStats are the reading stats of a single day
+type DailyReadingStats struct {
+	Date string `json:"date"`
+	ReadingStats
+}
+
+// PoemAnalytics are the reading stats of one poem
+type PoemAnalytics struct {
+	PoemID   uint   `json:"poem_id"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	AuthorID *uint  `json:"author_id"`
+	ReadingStats
+}
+
+// AuthorAnalytics are the reading stats of all poems by one author
+type AuthorAnalytics struct {
+	AuthorID uint   `json:"author_id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Poems    int64  `json:"poems"`
+	ReadingStats
+}
+
+// withRatio fills in the like-to-view ratio
+func (s ReadingStats) withRatio() ReadingStats {
+	if s.Views > 0 {
+		s.LikeToViewRatio = float64(s.Likes) / float64(s.Views)
+	}
+	return s
+}
+
+// analyticsWindow reads ?days= (default 30) and returns the first day and the matching start time
+func analyticsWindow(c *fiber.Ctx) (int, string, time.Time) {
+	days, _ := strconv.Atoi(c.Query("days", "30"))
+	if days <= 0 || days > maxAnalyticsDays {
+		days = 30
+	}
+	now := helpers.AppNow()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, helpers.AppLocation()).AddDate(0, 0, -(days - 1))
+	return days, start.Format(helpers.DateLayout), start
+}
+
+// readingStatsFor aggregates views and likes for the given poems since start
+func readingStatsFor(poemIDs []uint, fromDay string, since time.Time) ReadingStats {
+	var stats ReadingStats
+	if len(poemIDs) == 0 {
+		return stats
+	}
+
+	database.DB.Model(&models.PoemView{}).
+		Select("COUNT(*) AS views, COUNT(DISTINCT viewer_key) AS unique_readers").
+		Where("poem_id IN ? AND day >= ?", poemIDs, fromDay).
+		Scan(&stats)
+	database.DB.Table("admin_liked_poems").
+		Where("poem_id IN ? AND created_at >= ?", poemIDs, since).
+		Count(&stats.Likes)
+
+	return stats.withRatio()
+}
+
+// dailyReadingStatsFor returns one entry per day since fromDay, including days without views
+func dailyReadingStatsFor(poemIDs []uint, days int, fromDay string, since time.Time) []DailyReadingStats {
+	byDay := make(map[string]*DailyReadingStats, days)
+	series := make([]DailyReadingStats, days)
+	for i := range series {
+		series[i].Date = since.AddDate(0, 0, i).Format(helpers.DateLayout)
+		byDay[series[i].Date] = &series[i]
+	}
+	if len(poemIDs) == 0 {
+		return series
+	}
+
+	var views []DailyReadingStats
+	database.DB.Model(&models.PoemView{}).
+		Select("day AS date, COUNT(*) AS views, COUNT(DISTINCT viewer_key) AS unique_readers").
+		Where("poem_id IN ? AND day >= ?", poemIDs, fromDay).
+		Group("day").
+		Scan(&views)
+	for _, row := range views {
+		if entry, ok := byDay[row.Date]; ok {
+			entry.Views = row.Views
+			entry.UniqueReaders = row.UniqueReaders
+		}
+	}
+
+	var likes []DailyReadingStats
+	database.DB.Table("admin_liked_poems").
+		Select("to_char(created_at AT TIME ZONE ?, 'YYYY-MM-DD') AS date, COUNT(*) AS likes", helpers.AppLocation().String()).
+		Where("poem_id IN ? AND created_at >= ?", poemIDs, since).
+		Group("date").
+		Scan(&likes)
+	for _, row := range likes {
+		if entry, ok := byDay[row.Date]; ok {
+			entry.Likes = row.Likes
+		}
+	}
+
+	for i := range series {
+		series[i].ReadingStats = series[i].ReadingStats.withRatio()
+	}
+	return series
+}
+
+// GetPoemAnalytics lists poems by views in the window, optionally for one author (?author_id=)
+func GetPoemAnalytics(c *fiber.Ctx) error {
+	days, fromDay, since := analyticsWindow(c)
+	params := helpers.GetPaginationParams(c)
+
+	authorCondition := ""
+	args := map[string]interface{}{"from": fromDay, "since": since, "offset": params.Offset, "limit": params.Limit}
+	if authorID := c.Query("author_id"); authorID != "" {
+		id, err := strconv.Atoi(authorID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid author_id",
+			})
+		}
+		authorCondition = "AND poems.author_id = @author"
+		args["author"] = id
+	}
+
+	var poems []PoemAnalytics
+	database.DB.Raw(`SELECT poems.id AS poem_id, poems.title, poems.slug, poems.author_id,
+			COALESCE(views.views, 0) AS views,
+			COALESCE(views.unique_readers, 0) AS unique_readers,
+			COALESCE(likes.likes, 0) AS likes
+		FROM poems
+		LEFT JOIN (
+			SELECT poem_id, COUNT(*) AS views, COUNT(DISTINCT viewer_key) AS unique_readers
+			FROM poem_views WHERE day >= @from GROUP BY poem_id
+		) views ON views.poem_id = poems.id
+		LEFT JOIN (
+			SELECT poem_id, COUNT(*) AS likes
+			FROM admin_liked_poems WHERE created_at >= @since GROUP BY poem_id
+		) likes ON likes.poem_id = poems.id
+		WHERE poems.is_deleted = false `+authorCondition+`
+		ORDER BY views DESC, likes DESC, poems.id DESC
+		OFFSET @offset LIMIT @limit`, args).Scan(&poems)
+
+	for i := range poems {
+		poems[i].ReadingStats = poems[i].ReadingStats.withRatio()
+	}
+
+	var total int64
+	database.DB.Raw(`SELECT COUNT(*) FROM poems WHERE poems.is_deleted = false `+authorCondition, args).Scan(&total)
+
+	return c.JSON(fiber.Map{
+		"days":   days,
+		"from":   fromDay,
+		"result": helpers.CreatePaginationResponse(poems, total, params.Offset, params.Limit),
+	})
+}
+
+// GetPoemAnalyticsById returns totals and a daily series for a single poem
+func GetPoemAnalyticsById(c *fiber.Ctx) error {
+	days, fromDay, since := analyticsWindow(c)
+
+	var poem models.Poem
+	if err := database.DB.Preload("AuthorData").First(&poem, c.Params("id")).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Poem not found",
+		})
+	}
+
+	poemIDs := []uint{poem.ID}
+	return c.JSON(fiber.Map{
+		"poem":   poem,
+		"days":   days,
+		"from":   fromDay,
+		"totals": readingStatsFor(poemIDs, fromDay, since),
+		"series": dailyReadingStatsFor(poemIDs, days, fromDay, since),
+	})
+}
+
+// GetAuthorAnalytics lists authors by views of their poems in the window
+func GetAuthorAnalytics(c *fiber.Ctx) error {
+	days, fromDay, since := analyticsWindow(c)
+	params := helpers.GetPaginationParams(c)
+	args := map[string]interface{}{"from": fromDay, "since": since, "offset": params.Offset, "limit": params.Limit}
+
+	var authors []AuthorAnalytics
+	database.DB.Raw(`SELECT authors.id AS author_id, authors.name, authors.slug,
+			COUNT(DISTINCT poems.id) AS poems,
+			COUNT(poem_views.id) AS views,
+			COUNT(DISTINCT poem_views.viewer_key) AS unique_readers,
+			(SELECT COUNT(*) FROM admin_liked_poems
+				JOIN poems liked ON liked.id = admin_liked_poems.poem_id
+				WHERE liked.author_id = authors.id AND liked.is_deleted = false
+				AND admin_liked_poems.created_at >= @since) AS likes
+		FROM authors
+		JOIN poems ON poems.author_id = authors.id AND poems.is_deleted = false
+		LEFT JOIN poem_views ON poem_views.poem_id = poems.id AND poem_views.day >= @from
+		WHERE authors.is_deleted = false
+		GROUP BY authors.id, authors.name, authors.slug
+		ORDER BY views DESC, likes DESC, authors.id DESC
+		OFFSET @offset LIMIT @limit`, args).Scan(&authors)
+
+	for i := range authors {
+		authors[i].ReadingStats = authors[i].ReadingStats.withRatio()
+	}
+
+	var total int64
+	database.DB.Raw(`SELECT COUNT(DISTINCT authors.id) FROM authors
+		JOIN poems ON poems.author_id = authors.id AND poems.is_deleted = false
+		WHERE authors.is_deleted = false`).Scan(&total)
+
+	return c.JSON(fiber.Map{
+		"days":   days,
+		"from":   fromDay,
+		"result": helpers.CreatePaginationResponse(authors, total, params.Offset, params.Limit),
+	})
+}
+
+// GetAuthorAnalyticsById returns totals, a daily series and the most read poems of an author
+func GetAuthorAnalyticsById(c *fiber.Ctx) error {
+	days, fromDay, since := analyticsWindow(c)
+
+	var author models.Author
+	if err := database.DB.Where("is_deleted = ?", false).First(&author, c.Params("id")).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Author not found",
+		})
+	}
+
+	var poemIDs []uint
+	database.DB.Model(&models.Poem{}).
+		Where("author_id = ? AND is_deleted = ?", author.ID, false).
+		Pluck("id", &poemIDs)
+
+	var topPoems []PoemAnalytics
+	if len(poemIDs) > 0 {
+		database.DB.Raw(`SELECT poems.id AS poem_id, poems.title, poems.slug, poems.author_id,
+				COUNT(*) AS views, COUNT(DISTINCT poem_views.viewer_key) AS unique_readers
+			FROM poem_views
+			JOIN poems ON poems.id = poem_views.poem_id
+			WHERE poem_views.poem_id IN ? AND poem_views.day >= ?
+			GROUP BY poems.id, poems.title, poems.slug, poems.author_id
+			ORDER BY views DESC, poems.id DESC
+			LIMIT 10`, poemIDs, fromDay).Scan(&topPoems)
+	}
+
+	return c.JSON(fiber.Map{
+		"author":    author,
+		"days":      days,
+		"from":      fromDay,
+		"totals":    readingStatsFor(poemIDs, fromDay, since),
+		"series":    dailyReadingStatsFor(poemIDs, days, fromDay, since),
+		"top_poems": topPoems,
+	})
+}
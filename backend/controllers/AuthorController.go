@@ -4,6 +4,8 @@ import (
 	"backend/database"
 	"backend/helpers"
 	"backend/models"
+	"backend/security"
+	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -12,6 +14,31 @@ import (
 	"time"
 )
 
+// validateAuthorDates checks the optional full birth and death dates and keeps BirthYear and
+// DeathYear in line with them
+func validateAuthorDates(author *models.Author) error {
+	validator := security.NewValidator()
+	var err error
+	if author.BirthDate, err = validator.ValidateDate("birth_date", author.BirthDate); err != nil {
+		return err
+	}
+	if author.DeathDate, err = validator.ValidateDate("death_date", author.DeathDate); err != nil {
+		return err
+	}
+	if author.BirthDate != nil && author.DeathDate != nil && *author.DeathDate < *author.BirthDate {
+		return &security.ValidationError{Field: "death_date", Message: "must not be before birth_date"}
+	}
+	if author.BirthDate != nil {
+		year, _ := strconv.Atoi((*author.BirthDate)[:4])
+		author.BirthYear = &year
+	}
+	if author.DeathDate != nil {
+		year, _ := strconv.Atoi((*author.DeathDate)[:4])
+		author.DeathYear = &year
+	}
+	return nil
+}
+
 // CreateAuthor creates a new author
 func CreateAuthor(c *fiber.Ctx) error {
 	var author models.Author
@@ -42,10 +69,30 @@ func CreateAuthor(c *fiber.Ctx) error {
 	}
 	slug := replaceChars(author.Name, x)
 	author.Slug = strings.ToLower(slug)
+	author.Bio = security.NewSanitizer().SanitizeRichText(author.Bio)
 	author.CreatedAt = time.Now().Format("02-01-2006")
 	author.IsDeleted = false
+	if err := validateAuthorDates(&author); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	if err := database.DB.Create(&author).Error; err != nil {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		periods, err := resolvePeriods(tx, author.Periods)
+		if err != nil {
+			return err
+		}
+		author.Periods = periods
+		return tx.Create(&author).Error
+	})
+	if err != nil {
+		var validationErr *security.ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": validationErr.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to create author",
 		})
@@ -66,11 +113,12 @@ func GetAuthors(c *fiber.Ctx) error {
 
 	// Get total count
 	var total int64
-	database.DB.Model(&models.Author{}).Where("is_deleted = ?", false).Count(&total)
+	countQuery := database.DB.Model(&models.Author{}).Where("is_deleted = ?", false)
+	applyPeriodFilter(countQuery, c, "id").Count(&total)
 
-	// Get paginated authors with their poems and books
+	// Get paginated authors with their poems, books and periods
 	var authors []models.Author
-	query := database.DB.Where("is_deleted = ?", false)
+	query := applyPeriodFilter(database.DB.Where("is_deleted = ?", false), c, "id").Preload("Periods")
 
 	// Preload poems and books with community filtering
 	query = query.Preload("Poems", func(db *gorm.DB) *gorm.DB {
@@ -91,6 +139,7 @@ func GetAuthors(c *fiber.Ctx) error {
 		Limit(params.Limit).
 		Order("name ASC").
 		Find(&authors)
+	attachAuthorFollows(authors, GetUserId(c))
 
 	// Create paginated response
 	response := helpers.CreatePaginationResponse(authors, total, params.Offset, params.Limit)
@@ -111,7 +160,7 @@ func GetAuthor(c *fiber.Ctx) error {
 	}
 
 	var author models.Author
-	query := database.DB.Where("slug = ? AND is_deleted = ?", slug, false)
+	query := database.DB.Where("slug = ? AND is_deleted = ?", slug, false).Preload("Periods")
 
 	// Preload poems and books with community filtering
 	query = query.Preload("Poems", func(db *gorm.DB) *gorm.DB {
@@ -129,11 +178,23 @@ func GetAuthor(c *fiber.Ctx) error {
 	})
 
 	if err := query.First(&author).Error; err != nil {
+		// Slugs of merged or renamed authors point at the author that replaced them
+		var redirect models.AuthorSlugRedirect
+		var target models.Author
+		if database.DB.Where("slug = ?", slug).First(&redirect).Error == nil &&
+			database.DB.Select("id", "slug").Where("id = ? AND is_deleted = ?", redirect.AuthorID, false).First(&target).Error == nil {
+			return c.Redirect("/get-author/"+target.Slug, fiber.StatusMovedPermanently)
+		}
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "Author not found",
 		})
 	}
 
+	authors := []models.Author{author}
+	attachAuthorFollows(authors, userID)
+	author = authors[0]
+	author.WorksByRole = loadAuthorWorksByRole(author.ID, communityLevelForRole(roleID))
+
 	fmt.Printf("[GetAuthor] slug=%s, userID=%d, roleID=%d, poems=%d, books=%d\n",
 		slug, userID, roleID, len(author.Poems), len(author.Books))
 
@@ -159,6 +220,7 @@ func UpdateAuthor(c *fiber.Ctx) error {
 	}
 
 	// Update fields
+	previousSlug := author.Slug
 	if updateData.Name != "" {
 		author.Name = updateData.Name
 		// Regenerate slug if name changed
@@ -184,7 +246,7 @@ func UpdateAuthor(c *fiber.Ctx) error {
 		author.Slug = strings.ToLower(slug)
 	}
 	if updateData.Bio != "" {
-		author.Bio = updateData.Bio
+		author.Bio = security.NewSanitizer().SanitizeRichText(updateData.Bio)
 	}
 	if updateData.BirthYear != nil {
 		author.BirthYear = updateData.BirthYear
@@ -192,6 +254,18 @@ func UpdateAuthor(c *fiber.Ctx) error {
 	if updateData.DeathYear != nil {
 		author.DeathYear = updateData.DeathYear
 	}
+	// An empty date clears it
+	if updateData.BirthDate != nil {
+		author.BirthDate = updateData.BirthDate
+	}
+	if updateData.DeathDate != nil {
+		author.DeathDate = updateData.DeathDate
+	}
+	if err := validateAuthorDates(&author); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 	if updateData.Nationality != "" {
 		author.Nationality = updateData.Nationality
 	}
@@ -199,7 +273,36 @@ func UpdateAuthor(c *fiber.Ctx) error {
 		author.Image = updateData.Image
 	}
 
-	if err := database.DB.Save(&author).Error; err != nil {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if author.Slug != previousSlug {
+			if err := addAuthorSlugRedirect(tx, previousSlug, author.ID); err != nil {
+				return err
+			}
+			if err := tx.Where("slug = ?", author.Slug).Delete(&models.AuthorSlugRedirect{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(&author).Error; err != nil {
+			return err
+		}
+		// Periods are only replaced when the request sends them
+		if updateData.Periods == nil {
+			return nil
+		}
+		periods, err := resolvePeriods(tx, updateData.Periods)
+		if err != nil {
+			return err
+		}
+		author.Periods = periods
+		return tx.Model(&author).Association("Periods").Replace(periods)
+	})
+	if err != nil {
+		var validationErr *security.ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": validationErr.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update author",
 		})
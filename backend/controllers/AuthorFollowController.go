@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	ws "backend/websocket"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuthorFeedItem is a poem or book in the "new from authors you follow" feed
+type AuthorFeedItem struct {
+	Type      string         `json:"type"` // "poem" or "book"
+	ID        uint           `json:"id"`
+	Title     string         `json:"title"`
+	Slug      string         `json:"slug"`
+	CreatedAt string         `json:"created_at"`
+	Author    *models.Author `json:"author,omitempty"`
+
+	date time.Time
+}
+
+// attachAuthorFollows fills follower counts and whether the viewer follows each author
+func attachAuthorFollows(authors []models.Author, userID uint) {
+	if len(authors) == 0 {
+		return
+	}
+	authorIDs := make([]uint, len(authors))
+	for i, author := range authors {
+		authorIDs[i] = author.ID
+	}
+
+	var counts []struct {
+		AuthorID uint
+		Count    int64
+	}
+	database.DB.Model(&models.AuthorFollow{}).
+		Select("author_id, COUNT(*) AS count").
+		Where("author_id IN ?", authorIDs).
+		Group("author_id").
+		Scan(&counts)
+	countByAuthor := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countByAuthor[count.AuthorID] = count.Count
+	}
+
+	following := make(map[uint]bool)
+	if userID != 0 {
+		var followed []uint
+		database.DB.Model(&models.AuthorFollow{}).
+			Where("admin_id = ? AND author_id IN ?", userID, authorIDs).
+			Pluck("author_id", &followed)
+		for _, id := range followed {
+			following[id] = true
+		}
+	}
+
+	for i := range authors {
+		authors[i].FollowerCount = countByAuthor[authors[i].ID]
+		authors[i].IsFollowing = following[authors[i].ID]
+	}
+}
+
+// notifyAuthorFollowers stores a notification for every follower of the author who can see
+// the new poem or book and pushes it over WebSocket. The user who added it is skipped.
+// Callers run it in a goroutine so publishing does not wait for large follower lists.
+func notifyAuthorFollowers(authorID *uint, community int, notificationType string, contentID uint, title string, slug string, creatorID uint) {
+	if authorID == nil {
+		return
+	}
+	var author models.Author
+	if err := database.DB.Select("id", "name", "slug").Where("id = ? AND is_deleted = ?", *authorID, false).First(&author).Error; err != nil {
+		return
+	}
+
+	// Private content (community 1) is only visible to roles 1 and 2
+	query := database.DB.Model(&models.AuthorFollow{}).
+		Joins("JOIN admins ON admins.id = author_follows.admin_id").
+		Where("author_follows.author_id = ? AND author_follows.admin_id <> ?", author.ID, creatorID)
+	if community != 2 {
+		query = query.Where("admins.role_id IN ?", []uint{1, 2})
+	}
+	var followerIDs []uint
+	query.Pluck("author_follows.admin_id", &followerIDs)
+	if len(followerIDs) == 0 {
+		return
+	}
+
+	now := helpers.AppNow()
+	notifications := make([]models.Notification, len(followerIDs))
+	for i, followerID := range followerIDs {
+		notifications[i] = models.Notification{
+			AdminID:   followerID,
+			Type:      notificationType,
+			Title:     title,
+			Slug:      slug,
+			AuthorID:  &author.ID,
+			CreatedAt: now,
+		}
+		if notificationType == models.NotificationAuthorPoem {
+			notifications[i].PoemID = &contentID
+		} else {
+			notifications[i].BookID = &contentID
+		}
+	}
+	if err := database.DB.CreateInBatches(&notifications, 500).Error; err != nil {
+		fmt.Printf("[AuthorFollow] saving notifications for author %d failed: %v\n", author.ID, err)
+		return
+	}
+
+	unread := unreadNotificationCounts(followerIDs)
+	for _, notification := range notifications {
+		fmt.Printf("[WebSocket] Sending %s to user %d for author %d\n", notificationType, notification.AdminID, author.ID)
+		ws.GlobalHub.SendToUser(notification.AdminID, notificationType, map[string]interface{}{
+			"notification_id": notification.ID,
+			"author_id":       author.ID,
+			"author_name":     author.Name,
+			"author_slug":     author.Slug,
+			"title":           title,
+			"slug":            slug,
+			"poem_id":         notification.PoemID,
+			"book_id":         notification.BookID,
+			"unread_count":    unread[notification.AdminID],
+		})
+	}
+}
+
+// FollowAuthor subscribes the session user to an author's new poems and books
+func FollowAuthor(c *fiber.Ctx) error {
+	authorID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	if userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var author models.Author
+	if err := database.DB.Where("id = ? AND is_deleted = ?", authorID, false).First(&author).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yazar bulunamadı",
+		})
+	}
+
+	var existing int64
+	database.DB.Model(&models.AuthorFollow{}).Where("admin_id = ? AND author_id = ?", userID, author.ID).Count(&existing)
+	if existing == 0 {
+		if err := database.DB.Create(&models.AuthorFollow{AdminID: userID, AuthorID: author.ID}).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "Yazar takip edilemedi",
+			})
+		}
+	}
+
+	authors := []models.Author{author}
+	attachAuthorFollows(authors, userID)
+	return c.JSON(fiber.Map{
+		"message":        "Yazar takip ediliyor",
+		"follower_count": authors[0].FollowerCount,
+		"is_following":   true,
+	})
+}
+
+// UnfollowAuthor removes the session user's subscription to an author
+func UnfollowAuthor(c *fiber.Ctx) error {
+	authorID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+
+	if err := database.DB.Where("admin_id = ? AND author_id = ?", userID, authorID).Delete(&models.AuthorFollow{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Takip bırakılamadı",
+		})
+	}
+
+	var count int64
+	database.DB.Model(&models.AuthorFollow{}).Where("author_id = ?", authorID).Count(&count)
+	return c.JSON(fiber.Map{
+		"message":        "Yazar takipten çıkarıldı",
+		"follower_count": count,
+		"is_following":   false,
+	})
+}
+
+// GetFollowedAuthors lists the authors the session user follows, most recently followed first
+func GetFollowedAuthors(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	params := helpers.GetPaginationParams(c)
+
+	query := database.DB.Model(&models.AuthorFollow{}).
+		Joins("JOIN authors ON authors.id = author_follows.author_id").
+		Where("author_follows.admin_id = ? AND authors.is_deleted = ?", userID, false)
+
+	var total int64
+	query.Count(&total)
+
+	var follows []models.AuthorFollow
+	query.Preload("Author").
+		Order("author_follows.created_at DESC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&follows)
+
+	authors := make([]models.Author, 0, len(follows))
+	for _, follow := range follows {
+		if follow.Author != nil {
+			authors = append(authors, *follow.Author)
+		}
+	}
+	attachAuthorFollows(authors, userID)
+
+	return c.JSON(helpers.CreatePaginationResponse(authors, total, params.Offset, params.Limit))
+}
+
+// GetFollowedAuthorsFeed returns the poems and books of followed authors the session user can
+// see, newest first
+func GetFollowedAuthorsFeed(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+	params := helpers.GetPaginationParams(c)
+
+	followed := database.DB.Model(&models.AuthorFollow{}).Select("author_id").Where("admin_id = ?", userID)
+	poemQuery := applyCommunityFilter(database.DB.Model(&models.Poem{}), roleID).
+		Where("is_deleted = ? AND author_id IN (?)", false, followed)
+	bookQuery := applyCommunityFilterForBook(database.DB.Model(&models.Book{}), roleID).
+		Where("is_deleted = ? AND author_id IN (?)", false, followed)
+
+	var poemTotal, bookTotal int64
+	poemQuery.Count(&poemTotal)
+	bookQuery.Count(&bookTotal)
+
+	// Each list is newest first by id, so the first offset+limit of each is enough to build
+	// the requested page of the merged feed
+	window := params.Offset + params.Limit
+	var poems []models.Poem
+	poemQuery.Preload("AuthorData").Order("id DESC").Limit(window).Find(&poems)
+	var books []models.Book
+	bookQuery.Preload("AuthorData").Order("id DESC").Limit(window).Find(&books)
+
+	items := make([]AuthorFeedItem, 0, len(poems)+len(books))
+	for _, poem := range poems {
+		date, _ := time.Parse("02-01-2006", poem.CreatedAt)
+		items = append(items, AuthorFeedItem{
+			Type: "poem", ID: poem.ID, Title: poem.Title, Slug: poem.Slug,
+			CreatedAt: poem.CreatedAt, Author: poem.AuthorData, date: date,
+		})
+	}
+	for _, book := range books {
+		date, _ := time.Parse("02-01-2006", book.CreatedAt)
+		items = append(items, AuthorFeedItem{
+			Type: "book", ID: book.ID, Title: book.Name, Slug: book.Slug,
+			CreatedAt: book.CreatedAt, Author: book.AuthorData, date: date,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].date.After(items[j].date)
+	})
+
+	page := []AuthorFeedItem{}
+	if params.Offset < len(items) {
+		end := params.Offset + params.Limit
+		if end > len(items) {
+			end = len(items)
+		}
+		page = items[params.Offset:end]
+	}
+
+	return c.JSON(helpers.CreatePaginationResponse(page, poemTotal+bookTotal, params.Offset, params.Limit))
+}
@@ -0,0 +1,438 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Legacy author match kinds
+const (
+	LegacyAuthorExact = "match" // Same name after Turkish normalization
+	LegacyAuthorFuzzy = "fuzzy" // Close enough to propose, needs review
+	LegacyAuthorNew   = "new"   // No author is close; proposes creating one
+)
+
+const maxLegacyAuthorCandidates = 3
+
+// LegacyAuthorCandidate is an existing author a legacy name may refer to
+type LegacyAuthorCandidate struct {
+	AuthorID uint    `json:"author_id"`
+	Name     string  `json:"name"`
+	Slug     string  `json:"slug"`
+	Score    float64 `json:"score"`
+}
+
+// LegacyAuthorName is a distinct Poem.Author/Book.Author string of rows without an AuthorID,
+// with the proposed author for it
+type LegacyAuthorName struct {
+	Name       string                  `json:"name"`
+	PoemCount  int64                   `json:"poem_count"`
+	BookCount  int64                   `json:"book_count"`
+	Action     string                  `json:"action"`
+	AuthorID   *uint                   `json:"author_id,omitempty"` // Proposed author for match and fuzzy
+	NewName    string                  `json:"new_name,omitempty"`  // Proposed author name for new
+	Candidates []LegacyAuthorCandidate `json:"candidates"`
+}
+
+// loadLegacyAuthorNames collects the distinct legacy author strings of poems and books that
+// are not linked to an author yet
+func loadLegacyAuthorNames() []LegacyAuthorName {
+	var rows []struct {
+		Name  string
+		Kind  string
+		Count int64
+	}
+	database.DB.Raw(`
+		SELECT TRIM(author) AS name, 'poem' AS kind, COUNT(*) AS count FROM poems
+		WHERE author_id IS NULL AND TRIM(COALESCE(author, '')) <> '' GROUP BY TRIM(author)
+		UNION ALL
+		SELECT TRIM(author) AS name, 'book' AS kind, COUNT(*) AS count FROM books
+		WHERE author_id IS NULL AND TRIM(COALESCE(author, '')) <> '' GROUP BY TRIM(author)`).
+		Scan(&rows)
+
+	byName := map[string]*LegacyAuthorName{}
+	names := []string{}
+	for _, row := range rows {
+		entry, ok := byName[row.Name]
+		if !ok {
+			entry = &LegacyAuthorName{Name: row.Name}
+			byName[row.Name] = entry
+			names = append(names, row.Name)
+		}
+		if row.Kind == "poem" {
+			entry.PoemCount += row.Count
+		} else {
+			entry.BookCount += row.Count
+		}
+	}
+	sort.Strings(names)
+
+	result := make([]LegacyAuthorName, len(names))
+	for i, name := range names {
+		result[i] = *byName[name]
+	}
+	return result
+}
+
+// matchLegacyAuthor proposes an author for a legacy name: an exact match after normalization,
+// else the closest author at or above the threshold, else a new author named after the string
+func matchLegacyAuthor(entry *LegacyAuthorName, authors []models.Author, threshold float64) {
+	entry.Candidates = []LegacyAuthorCandidate{}
+	key := helpers.NormalizeName(entry.Name)
+	for _, author := range authors {
+		if helpers.NormalizeName(author.Name) == key || author.Slug == helpers.Slugify(entry.Name) {
+			entry.Action = LegacyAuthorExact
+			entry.AuthorID = &author.ID
+			entry.Candidates = append(entry.Candidates, LegacyAuthorCandidate{
+				AuthorID: author.ID, Name: author.Name, Slug: author.Slug, Score: 1,
+			})
+			return
+		}
+	}
+
+	for _, author := range authors {
+		score := helpers.NameSimilarity(entry.Name, author.Name)
+		if score < threshold {
+			continue
+		}
+		entry.Candidates = append(entry.Candidates, LegacyAuthorCandidate{
+			AuthorID: author.ID, Name: author.Name, Slug: author.Slug, Score: score,
+		})
+	}
+	sort.SliceStable(entry.Candidates, func(i, j int) bool {
+		return entry.Candidates[i].Score > entry.Candidates[j].Score
+	})
+	if len(entry.Candidates) > maxLegacyAuthorCandidates {
+		entry.Candidates = entry.Candidates[:maxLegacyAuthorCandidates]
+	}
+
+	if len(entry.Candidates) > 0 {
+		entry.Action = LegacyAuthorFuzzy
+		entry.AuthorID = &entry.Candidates[0].AuthorID
+		return
+	}
+	entry.Action = LegacyAuthorNew
+	entry.NewName = strings.Join(strings.Fields(entry.Name), " ")
+}
+
+// GetLegacyAuthorMatches lists the legacy author strings still in use with a proposed author for
+// each. Nothing is changed; the reviewed list is sent to ApplyLegacyAuthorMatches.
+func GetLegacyAuthorMatches(c *fiber.Ctx) error {
+	threshold := helpers.GetEnvFloat("LEGACY_AUTHOR_MATCH_THRESHOLD", 0.85)
+
+	var authors []models.Author
+	database.DB.Select("id", "name", "slug").Where("is_deleted = ?", false).Find(&authors)
+
+	names := loadLegacyAuthorNames()
+	summary := map[string]int{LegacyAuthorExact: 0, LegacyAuthorFuzzy: 0, LegacyAuthorNew: 0}
+	for i := range names {
+		matchLegacyAuthor(&names[i], authors, threshold)
+		summary[names[i].Action]++
+	}
+
+	return c.JSON(fiber.Map{
+		"threshold": threshold,
+		"summary":   summary,
+		"names":     names,
+	})
+}
+
+// LegacyAuthorMapping is a reviewed decision for one legacy author string: link it to
+// AuthorID, or create an author named NewName (the string itself when empty)
+type LegacyAuthorMapping struct {
+	Name     string `json:"name"`
+	AuthorID *uint  `json:"author_id"`
+	Create   bool   `json:"create"`
+	NewName  string `json:"new_name"`
+}
+
+// errLegacyAuthorMapping marks a mapping the admin has to fix
+var errLegacyAuthorMapping = errors.New("invalid mapping")
+
+// ApplyLegacyAuthorMatches links poems and books without an AuthorID to authors according to
+// the reviewed mappings, creating the requested new authors. All mappings apply in one
+// transaction; the legacy strings are left in place.
+func ApplyLegacyAuthorMatches(c *fiber.Ctx) error {
+	var data struct {
+		Mappings []LegacyAuthorMapping `json:"mappings"`
+	}
+	if err := c.BodyParser(&data); err != nil || len(data.Mappings) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Eşleştirme listesi gerekli",
+		})
+	}
+
+	var poemsLinked, booksLinked int64
+	created := []models.Author{}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// Several legacy spellings can ask for the same new author
+		createdBySlug := map[string]uint{}
+		for _, mapping := range data.Mappings {
+			name := strings.TrimSpace(mapping.Name)
+			if name == "" {
+				return fmt.Errorf("%w: empty name", errLegacyAuthorMapping)
+			}
+
+			var authorID uint
+			switch {
+			case mapping.Create:
+				newName := strings.Join(strings.Fields(mapping.NewName), " ")
+				if newName == "" {
+					newName = strings.Join(strings.Fields(name), " ")
+				}
+				slug := helpers.Slugify(newName)
+				if id, ok := createdBySlug[slug]; ok {
+					authorID = id
+					break
+				}
+				var taken int64
+				tx.Model(&models.Author{}).Where("slug = ?", slug).Count(&taken)
+				if taken > 0 {
+					return fmt.Errorf("%w: an author with slug %q already exists, map %q to it instead", errLegacyAuthorMapping, slug, name)
+				}
+				author := models.Author{
+					Name:      newName,
+					Slug:      slug,
+					CreatedAt: time.Now().Format("02-01-2006"),
+				}
+				if err := tx.Create(&author).Error; err != nil {
+					return err
+				}
+				createdBySlug[slug] = author.ID
+				created = append(created, author)
+				authorID = author.ID
+			case mapping.AuthorID != nil:
+				var count int64
+				tx.Model(&models.Author{}).Where("id = ? AND is_deleted = ?", *mapping.AuthorID, false).Count(&count)
+				if count == 0 {
+					return fmt.Errorf("%w: author %d not found for %q", errLegacyAuthorMapping, *mapping.AuthorID, name)
+				}
+				authorID = *mapping.AuthorID
+			default:
+				return fmt.Errorf("%w: %q needs author_id or create", errLegacyAuthorMapping, name)
+			}
+
+			result := tx.Model(&models.Poem{}).
+				Where("author_id IS NULL AND TRIM(author) = ?", name).
+				Update("author_id", authorID)
+			if result.Error != nil {
+				return result.Error
+			}
+			poemsLinked += result.RowsAffected
+			result = tx.Model(&models.Book{}).
+				Where("author_id IS NULL AND TRIM(author) = ?", name).
+				Update("author_id", authorID)
+			if result.Error != nil {
+				return result.Error
+			}
+			booksLinked += result.RowsAffected
+		}
+		return database.SyncPrimaryContributors(tx)
+	})
+	if errors.Is(err, errLegacyAuthorMapping) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Eşleştirmeler uygulanamadı",
+		})
+	}
+
+	fmt.Printf("[LegacyAuthors] mappings=%d, poems=%d, books=%d, created=%d\n",
+		len(data.Mappings), poemsLinked, booksLinked, len(created))
+
+	return c.JSON(fiber.Map{
+		"poems_linked":    poemsLinked,
+		"books_linked":    booksLinked,
+		"created_authors": created,
+		"remaining":       len(loadLegacyAuthorNames()),
+	})
+}
+
+// addAuthorSlugRedirect points an old slug at the author that now owns its content
+func addAuthorSlugRedirect(tx *gorm.DB, slug string, authorID uint) error {
+	if slug == "" {
+		return nil
+	}
+	var redirect models.AuthorSlugRedirect
+	if err := tx.Where("slug = ?", slug).First(&redirect).Error; err == nil {
+		return tx.Model(&redirect).Update("author_id", authorID).Error
+	}
+	return tx.Create(&models.AuthorSlugRedirect{Slug: slug, AuthorID: authorID}).Error
+}
+
+// repeatedContributionIDs returns the contributions that repeat the same role on the same
+// poem or book, keeping the one with the lowest position (then id) of each
+func repeatedContributionIDs(contributions []models.WorkContributor) []uint {
+	sorted := append([]models.WorkContributor(nil), contributions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Position != sorted[j].Position {
+			return sorted[i].Position < sorted[j].Position
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	kept := map[string]bool{}
+	var repeated []uint
+	for _, contribution := range sorted {
+		var poemID, bookID uint
+		if contribution.PoemID != nil {
+			poemID = *contribution.PoemID
+		}
+		if contribution.BookID != nil {
+			bookID = *contribution.BookID
+		}
+		key := fmt.Sprintf("%d:%d:%s", poemID, bookID, contribution.Role)
+		if kept[key] {
+			repeated = append(repeated, contribution.ID)
+			continue
+		}
+		kept[key] = true
+	}
+	return repeated
+}
+
+// mergeAuthorsInto moves everything linked to the duplicates, including their literary
+// periods, onto the canonical author, keeps the duplicates' slugs as redirects and
+// soft-deletes them. Empty profile fields of the canonical author are filled from the
+// duplicates.
+func mergeAuthorsInto(tx *gorm.DB, canonical *models.Author, duplicates []models.Author) error {
+	ids := make([]uint, len(duplicates))
+	for i, duplicate := range duplicates {
+		ids[i] = duplicate.ID
+	}
+
+	for _, model := range []interface{}{&models.Poem{}, &models.Book{}, &models.Work{}, &models.Notification{}} {
+		if err := tx.Model(model).Where("author_id IN ?", ids).Update("author_id", canonical.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	// Followers of both keep a single follow
+	if err := tx.Where("author_id IN ? AND admin_id IN (?)", ids,
+		tx.Model(&models.AuthorFollow{}).Select("admin_id").Where("author_id = ?", canonical.ID)).
+		Delete(&models.AuthorFollow{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec(`DELETE FROM author_follows WHERE author_id IN ? AND id NOT IN (
+		SELECT MIN(id) FROM author_follows WHERE author_id IN ? GROUP BY admin_id)`, ids, ids).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.AuthorFollow{}).Where("author_id IN ?", ids).Update("author_id", canonical.ID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`INSERT INTO author_periods (author_id, literary_period_id)
+		SELECT DISTINCT ?::bigint, literary_period_id FROM author_periods WHERE author_id IN ?
+		ON CONFLICT DO NOTHING`, canonical.ID, ids).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM author_periods WHERE author_id IN ?", ids).Error; err != nil {
+		return err
+	}
+
+	// Contributions move to the canonical author; when both held the same role on a work
+	// the lower position is kept
+	if err := tx.Model(&models.WorkContributor{}).Where("author_id IN ?", ids).Update("author_id", canonical.ID).Error; err != nil {
+		return err
+	}
+	var contributions []models.WorkContributor
+	if err := tx.Where("author_id = ?", canonical.ID).Find(&contributions).Error; err != nil {
+		return err
+	}
+	if repeated := repeatedContributionIDs(contributions); len(repeated) > 0 {
+		if err := tx.Delete(&models.WorkContributor{}, repeated).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(&models.AuthorSlugRedirect{}).Where("author_id IN ?", ids).Update("author_id", canonical.ID).Error; err != nil {
+		return err
+	}
+	for _, duplicate := range duplicates {
+		if duplicate.Slug != canonical.Slug {
+			if err := addAuthorSlugRedirect(tx, duplicate.Slug, canonical.ID); err != nil {
+				return err
+			}
+		}
+		if canonical.Bio == "" {
+			canonical.Bio = duplicate.Bio
+		}
+		if canonical.BirthYear == nil {
+			canonical.BirthYear = duplicate.BirthYear
+		}
+		if canonical.DeathYear == nil {
+			canonical.DeathYear = duplicate.DeathYear
+		}
+		if canonical.Nationality == "" {
+			canonical.Nationality = duplicate.Nationality
+		}
+		if canonical.Image == "" {
+			canonical.Image = duplicate.Image
+		}
+	}
+
+	if err := tx.Model(&models.Author{}).Where("id IN ?", ids).Update("is_deleted", true).Error; err != nil {
+		return err
+	}
+	return tx.Save(canonical).Error
+}
+
+// MergeAuthors merges duplicate authors ("duplicate_ids") into a canonical one ("canonical_id")
+func MergeAuthors(c *fiber.Ctx) error {
+	var data struct {
+		CanonicalID  uint   `json:"canonical_id"`
+		DuplicateIDs []uint `json:"duplicate_ids"`
+	}
+	if err := c.BodyParser(&data); err != nil || data.CanonicalID == 0 || len(data.DuplicateIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "canonical_id ve duplicate_ids gerekli",
+		})
+	}
+	for _, id := range data.DuplicateIDs {
+		if id == data.CanonicalID {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Yazar kendisiyle birleştirilemez",
+			})
+		}
+	}
+
+	var canonical models.Author
+	if err := database.DB.Where("id = ? AND is_deleted = ?", data.CanonicalID, false).First(&canonical).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yazar bulunamadı",
+		})
+	}
+	var duplicates []models.Author
+	database.DB.Where("id IN ? AND is_deleted = ?", data.DuplicateIDs, false).Find(&duplicates)
+	if len(duplicates) != len(data.DuplicateIDs) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Birleştirilecek yazarlardan biri bulunamadı",
+		})
+	}
+
+	if err := database.DB.Transaction(func(tx *gorm.DB) error {
+		return mergeAuthorsInto(tx, &canonical, duplicates)
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Yazarlar birleştirilemedi",
+		})
+	}
+
+	fmt.Printf("[MergeAuthors] canonical=%d, duplicates=%v\n", canonical.ID, data.DuplicateIDs)
+
+	authors := []models.Author{canonical}
+	attachAuthorFollows(authors, GetUserId(c))
+	return c.JSON(authors[0])
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"backend/models"
+	"testing"
+)
+
+func TestMatchLegacyAuthor(t *testing.T) {
+	authors := []models.Author{
+		{ID: 1, Name: "Nazım Hikmet", Slug: "nazim-hikmet"},
+		{ID: 2, Name: "Sabahattin Ali", Slug: "sabahattin-ali"},
+		{ID: 3, Name: "Orhan Veli Kanık", Slug: "orhan-veli-kanik"},
+	}
+	tests := []struct {
+		name       string
+		action     string
+		authorID   uint
+		newName    string
+		candidates int
+	}{
+		{"nazım  HİKMET", LegacyAuthorExact, 1, "", 1},
+		{"Nazim Hikmet", LegacyAuthorExact, 1, "", 1},
+		{"Sabahatin Ali", LegacyAuthorFuzzy, 2, "", 1},
+		{"Hikmet Nazım", LegacyAuthorFuzzy, 1, "", 1},
+		{"  Cemal   Süreya ", LegacyAuthorNew, 0, "Cemal Süreya", 0},
+	}
+	for _, tt := range tests {
+		entry := LegacyAuthorName{Name: tt.name}
+		matchLegacyAuthor(&entry, authors, 0.85)
+		if entry.Action != tt.action {
+			t.Errorf("%q: action = %q, want %q", tt.name, entry.Action, tt.action)
+			continue
+		}
+		if tt.authorID == 0 {
+			if entry.AuthorID != nil {
+				t.Errorf("%q: proposed author %d, want none", tt.name, *entry.AuthorID)
+			}
+		} else if entry.AuthorID == nil || *entry.AuthorID != tt.authorID {
+			t.Errorf("%q: proposed author = %v, want %d", tt.name, entry.AuthorID, tt.authorID)
+		}
+		if entry.NewName != tt.newName {
+			t.Errorf("%q: new name = %q, want %q", tt.name, entry.NewName, tt.newName)
+		}
+		if len(entry.Candidates) != tt.candidates {
+			t.Errorf("%q: %d candidates, want %d", tt.name, len(entry.Candidates), tt.candidates)
+		}
+	}
+}
+
+func TestMatchLegacyAuthorRanksCandidates(t *testing.T) {
+	authors := []models.Author{
+		{ID: 1, Name: "Ahmet Haşim Bey"},
+		{ID: 2, Name: "Ahmet Haşimi"},
+	}
+	entry := LegacyAuthorName{Name: "Ahmet Hasim"}
+	matchLegacyAuthor(&entry, authors, 0.7)
+	if entry.Action != LegacyAuthorFuzzy || len(entry.Candidates) != 2 {
+		t.Fatalf("got action %q with %d candidates, want fuzzy with 2", entry.Action, len(entry.Candidates))
+	}
+	if entry.Candidates[0].AuthorID != 2 || *entry.AuthorID != 2 {
+		t.Errorf("best candidate = %d, want 2", entry.Candidates[0].AuthorID)
+	}
+	if entry.Candidates[0].Score < entry.Candidates[1].Score {
+		t.Errorf("candidates not ordered by score: %+v", entry.Candidates)
+	}
+}
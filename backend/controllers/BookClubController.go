@@ -0,0 +1,1146 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	ws "backend/websocket"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxClubMembers         = 100
+	maxClubPostLength      = 5000
+	maxClubScheduleWeeks   = 52
+	clubActivityFeedLength = 50
+)
+
+// BookClubScheduleWeek is a schedule segment with the viewer's reading state for it
+type BookClubScheduleWeek struct {
+	models.BookClubSegment
+	Current  bool `json:"current"`  // The week the calendar is in
+	Unlocked bool `json:"unlocked"` // The viewer has read to the end of the segment
+}
+
+// BookClubMemberProgress is a member's progress on the club's current book
+type BookClubMemberProgress struct {
+	AdminID      uint    `json:"admin_id"`
+	Username     string  `json:"username"`
+	ProfileImage string  `json:"profile_image"`
+	CurrentPage  int     `json:"current_page"`
+	Percentage   float64 `json:"percentage"`
+	Status       string  `json:"status"`
+}
+
+// findClubMembership returns the user's membership row in a club, whatever its status
+func findClubMembership(clubID uint, userID uint) (models.BookClubMember, bool) {
+	var member models.BookClubMember
+	err := database.DB.Where("club_id = ? AND admin_id = ?", clubID, userID).First(&member).Error
+	return member, err == nil
+}
+
+// isClubMember reports whether the user is an accepted member of the club
+func isClubMember(clubID uint, userID uint) bool {
+	member, ok := findClubMembership(clubID, userID)
+	return ok && member.Status == models.ClubMemberAccepted
+}
+
+// clubMemberIDs returns the accepted members of a club
+func clubMemberIDs(clubID uint) []uint {
+	var ids []uint
+	database.DB.Model(&models.BookClubMember{}).
+		Where("club_id = ? AND status = ?", clubID, models.ClubMemberAccepted).
+		Pluck("admin_id", &ids)
+	return ids
+}
+
+// loadMemberClub loads a club the session user is an accepted member of. Admins can open
+// any club for moderation. When ok is false the error response has been written.
+func loadMemberClub(c *fiber.Ctx) (club models.BookClub, ok bool, err error) {
+	clubID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	roleID, _ := helpers.GetUserRole(c)
+
+	if err := database.DB.First(&club, clubID).Error; err != nil {
+		return club, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kulüp bulunamadı",
+		})
+	}
+	if !isClubMember(club.ID, userID) && !isModerator(roleID) {
+		return club, false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu kulübün yalnızca üyeleri görebilir",
+		})
+	}
+	return club, true, nil
+}
+
+// attachClubMembership fills member counts and the viewer's status on clubs
+func attachClubMembership(clubs []models.BookClub, userID uint) {
+	if len(clubs) == 0 {
+		return
+	}
+	clubIDs := make([]uint, len(clubs))
+	for i, club := range clubs {
+		clubIDs[i] = club.ID
+	}
+
+	var counts []struct {
+		ClubID uint
+		Count  int64
+	}
+	database.DB.Model(&models.BookClubMember{}).
+		Select("club_id, COUNT(*) AS count").
+		Where("club_id IN ? AND status = ?", clubIDs, models.ClubMemberAccepted).
+		Group("club_id").
+		Scan(&counts)
+	countByClub := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countByClub[count.ClubID] = count.Count
+	}
+
+	var mine []models.BookClubMember
+	database.DB.Where("club_id IN ? AND admin_id = ?", clubIDs, userID).Find(&mine)
+	statusByClub := make(map[uint]string, len(mine))
+	for _, member := range mine {
+		statusByClub[member.ClubID] = member.Status
+	}
+
+	for i := range clubs {
+		clubs[i].MemberCount = countByClub[clubs[i].ID]
+		clubs[i].MyStatus = statusByClub[clubs[i].ID]
+	}
+}
+
+// recordClubActivity adds an entry to a club's activity feed
+func recordClubActivity(tx *gorm.DB, activity models.BookClubActivity) error {
+	if activity.CreatedAt.IsZero() {
+		activity.CreatedAt = helpers.AppNow()
+	}
+	return tx.Create(&activity).Error
+}
+
+// recordClubBookFinished notes in the feed of every club reading the book that the user finished it
+func recordClubBookFinished(tx *gorm.DB, userID uint, bookID uint, now time.Time) error {
+	var clubIDs []uint
+	tx.Model(&models.BookClub{}).
+		Joins("JOIN book_club_members ON book_club_members.club_id = book_clubs.id").
+		Where("book_clubs.current_book_id = ? AND book_club_members.admin_id = ? AND book_club_members.status = ?",
+			bookID, userID, models.ClubMemberAccepted).
+		Pluck("book_clubs.id", &clubIDs)
+	for _, clubID := range clubIDs {
+		if err := recordClubActivity(tx, models.BookClubActivity{
+			ClubID:    clubID,
+			AdminID:   userID,
+			Type:      models.ClubActivityFinished,
+			BookID:    &bookID,
+			CreatedAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pendingClubRequestCount counts invitations to the user and requests to clubs the user owns
+func pendingClubRequestCount(userID uint) int64 {
+	var count int64
+	database.DB.Model(&models.BookClubMember{}).
+		Joins("JOIN book_clubs ON book_clubs.id = book_club_members.club_id").
+		Where("(book_club_members.admin_id = ? AND book_club_members.status = ?) OR (book_clubs.owner_id = ? AND book_club_members.status = ?)",
+			userID, models.ClubMemberInvited, userID, models.ClubMemberRequested).
+		Count(&count)
+	return count
+}
+
+// notifyClubRequests sends the user their pending club request count
+func notifyClubRequests(userID uint, eventType string, extra map[string]interface{}) {
+	payload := map[string]interface{}{
+		"count": pendingClubRequestCount(userID),
+	}
+	for key, value := range extra {
+		payload[key] = value
+	}
+	fmt.Printf("[WebSocket] Sending %s to user %d, count: %v\n", eventType, userID, payload["count"])
+	ws.GlobalHub.SendToUser(userID, eventType, payload)
+}
+
+// errClubFull is returned when a club already has maxClubMembers members
+var errClubFull = fmt.Errorf("Kulüp en fazla %d üye alabilir", maxClubMembers)
+
+// acceptClubMembership turns a request or invitation into a membership. The club row is
+// locked while members are counted so concurrent accepts cannot exceed maxClubMembers.
+func acceptClubMembership(member *models.BookClubMember) error {
+	now := helpers.AppNow()
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var club models.BookClub
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&club, member.ClubID).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.BookClubMember{}).
+			Where("club_id = ? AND status = ?", member.ClubID, models.ClubMemberAccepted).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxClubMembers {
+			return errClubFull
+		}
+
+		member.Status = models.ClubMemberAccepted
+		member.JoinedAt = &now
+		if err := tx.Save(member).Error; err != nil {
+			return err
+		}
+		return recordClubActivity(tx, models.BookClubActivity{
+			ClubID:    member.ClubID,
+			AdminID:   member.AdminID,
+			Type:      models.ClubActivityJoined,
+			CreatedAt: now,
+		})
+	})
+}
+
+// acceptClubMembershipError writes the response for an error from acceptClubMembership
+func acceptClubMembershipError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errClubFull) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": "Üyelik kabul edilemedi",
+	})
+}
+
+// clubReadingPage returns how far the user has read the book: their current page, or the
+// whole book once finished
+func clubReadingPage(userID uint, bookID uint) int {
+	var progress models.BookProgress
+	if err := database.DB.Where("admin_id = ? AND book_id = ?", userID, bookID).First(&progress).Error; err != nil {
+		return 0
+	}
+	if progress.Status == models.ShelfFinished {
+		return math.MaxInt32
+	}
+	return progress.CurrentPage
+}
+
+// buildClubSchedule splits a book into weekly segments, either from explicit page ranges
+// or evenly over a number of weeks
+func buildClubSchedule(club models.BookClub, book models.Book, startsOn time.Time, weeks int, ranges [][2]int) ([]models.BookClubSegment, error) {
+	if len(ranges) == 0 {
+		if book.Page <= 0 {
+			return nil, fmt.Errorf("Kitabın sayfa sayısı bilinmiyor, sayfa aralıklarını girin")
+		}
+		if weeks <= 0 || weeks > maxClubScheduleWeeks || weeks > book.Page {
+			return nil, fmt.Errorf("Hafta sayısı 1-%d arasında olmalı", maxClubScheduleWeeks)
+		}
+		perWeek := float64(book.Page) / float64(weeks)
+		start := 1
+		for week := 1; week <= weeks; week++ {
+			end := int(math.Round(perWeek * float64(week)))
+			if week == weeks {
+				end = book.Page
+			}
+			ranges = append(ranges, [2]int{start, end})
+			start = end + 1
+		}
+	}
+	if len(ranges) > maxClubScheduleWeeks {
+		return nil, fmt.Errorf("Program en fazla %d hafta olabilir", maxClubScheduleWeeks)
+	}
+
+	segments := make([]models.BookClubSegment, 0, len(ranges))
+	previousEnd := 0
+	for i, pages := range ranges {
+		if pages[0] < 1 || pages[1] < pages[0] || pages[0] <= previousEnd ||
+			(book.Page > 0 && pages[1] > book.Page) {
+			return nil, fmt.Errorf("%d. haftanın sayfa aralığı geçersiz", i+1)
+		}
+		previousEnd = pages[1]
+		segments = append(segments, models.BookClubSegment{
+			ClubID:    club.ID,
+			BookID:    book.ID,
+			Week:      i + 1,
+			StartPage: pages[0],
+			EndPage:   pages[1],
+			StartsOn:  startsOn.AddDate(0, 0, 7*i),
+		})
+	}
+	return segments, nil
+}
+
+// CreateBookClub creates a club owned by the session user
+func CreateBookClub(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	if userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	sanitizer := security.NewSanitizer()
+	name := strings.TrimSpace(sanitizer.SanitizePlainText(data.Name))
+	if name == "" || len([]rune(name)) > 100 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Kulüp adı 1-100 karakter olmalı",
+		})
+	}
+
+	now := helpers.AppNow()
+	club := models.BookClub{
+		OwnerID:     userID,
+		Name:        name,
+		Description: sanitizer.SanitizePlainText(data.Description),
+	}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&club).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.BookClubMember{
+			ClubID:   club.ID,
+			AdminID:  userID,
+			Status:   models.ClubMemberAccepted,
+			JoinedAt: &now,
+		}).Error; err != nil {
+			return err
+		}
+		return recordClubActivity(tx, models.BookClubActivity{
+			ClubID:  club.ID,
+			AdminID: userID,
+			Type:    models.ClubActivityJoined,
+		})
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Kulüp oluşturulamadı",
+		})
+	}
+
+	club.MemberCount = 1
+	club.MyStatus = models.ClubMemberAccepted
+	return c.Status(fiber.StatusCreated).JSON(club)
+}
+
+// GetBookClubs lists the session user's clubs, invitations and requests, or with
+// ?scope=discover every club (name, description and size only) with ?search=
+func GetBookClubs(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	params := helpers.GetPaginationParams(c)
+
+	query := database.DB.Model(&models.BookClub{})
+	if c.Query("scope") != "discover" {
+		query = query.Where("id IN (?)", database.DB.Model(&models.BookClubMember{}).
+			Select("club_id").Where("admin_id = ?", userID))
+	}
+	if search := security.NewSanitizer().SanitizeSQLLike(c.Query("search")); search != "" {
+		query = query.Where("name ILIKE ?", "%"+search+"%")
+	}
+
+	var total int64
+	query.Count(&total)
+
+	clubs := []models.BookClub{}
+	query.Preload("Owner", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "profile_image")
+	}).
+		Order("updated_at DESC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&clubs)
+	attachClubMembership(clubs, userID)
+
+	return c.JSON(helpers.CreatePaginationResponse(clubs, total, params.Offset, params.Limit))
+}
+
+// GetBookClub returns a club. Members also get the member list with progress on the current
+// book and the pending requests (owner); others only see the summary.
+func GetBookClub(c *fiber.Ctx) error {
+	clubID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	roleID, _ := helpers.GetUserRole(c)
+
+	var club models.BookClub
+	if err := database.DB.Preload("Owner", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "profile_image")
+	}).First(&club, clubID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kulüp bulunamadı",
+		})
+	}
+	clubs := []models.BookClub{club}
+	attachClubMembership(clubs, userID)
+	club = clubs[0]
+
+	if club.MyStatus != models.ClubMemberAccepted && !isModerator(roleID) {
+		return c.JSON(fiber.Map{
+			"club": club,
+		})
+	}
+
+	if club.CurrentBookID != nil {
+		club.CurrentBook = &models.Book{}
+		// Members-only books stay hidden from users who cannot see them elsewhere
+		if err := applyCommunityFilterForBook(database.DB.Preload("AuthorData"), roleID).First(club.CurrentBook, *club.CurrentBookID).Error; err != nil {
+			club.CurrentBook = nil
+		}
+	}
+
+	members := []BookClubMemberProgress{}
+	query := database.DB.Table("book_club_members").
+		Select("admins.id AS admin_id, admins.username, admins.profile_image, " +
+			"COALESCE(book_progresses.current_page, 0) AS current_page, " +
+			"COALESCE(book_progresses.percentage, 0) AS percentage, " +
+			"COALESCE(book_progresses.status, '') AS status").
+		Joins("JOIN admins ON admins.id = book_club_members.admin_id")
+	if club.CurrentBookID != nil {
+		query = query.Joins("LEFT JOIN book_progresses ON book_progresses.admin_id = book_club_members.admin_id AND book_progresses.book_id = ?", *club.CurrentBookID)
+	} else {
+		query = query.Joins("LEFT JOIN book_progresses ON FALSE")
+	}
+	query.Where("book_club_members.club_id = ? AND book_club_members.status = ?", club.ID, models.ClubMemberAccepted).
+		Order("admins.username ASC").
+		Scan(&members)
+
+	response := fiber.Map{
+		"club":    club,
+		"members": members,
+	}
+	if club.OwnerID == userID {
+		pending := []models.BookClubMember{}
+		database.DB.Where("club_id = ? AND status <> ?", club.ID, models.ClubMemberAccepted).
+			Preload("Admin", func(db *gorm.DB) *gorm.DB {
+				return db.Select("id", "username", "profile_image")
+			}).
+			Order("created_at DESC").
+			Find(&pending)
+		response["pending"] = pending
+	}
+	return c.JSON(response)
+}
+
+// UpdateBookClub renames or redescribes a club (owner)
+func UpdateBookClub(c *fiber.Ctx) error {
+	club, ok, err := loadMemberClub(c)
+	if !ok {
+		return err
+	}
+	if club.OwnerID != GetUserId(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Kulübü yalnızca sahibi düzenleyebilir",
+		})
+	}
+
+	var data struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	sanitizer := security.NewSanitizer()
+	if data.Name != nil {
+		name := strings.TrimSpace(sanitizer.SanitizePlainText(*data.Name))
+		if name == "" || len([]rune(name)) > 100 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Kulüp adı 1-100 karakter olmalı",
+			})
+		}
+		club.Name = name
+	}
+	if data.Description != nil {
+		club.Description = sanitizer.SanitizePlainText(*data.Description)
+	}
+	if err := database.DB.Save(&club).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Kulüp güncellenemedi",
+		})
+	}
+	return c.JSON(club)
+}
+
+// DeleteBookClub deletes a club with its schedule, discussions and memberships (owner or admin)
+func DeleteBookClub(c *fiber.Ctx) error {
+	clubID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	roleID, _ := helpers.GetUserRole(c)
+
+	var club models.BookClub
+	if err := database.DB.First(&club, clubID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kulüp bulunamadı",
+		})
+	}
+	if club.OwnerID != userID && !isModerator(roleID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu kulübü silme yetkiniz yok",
+		})
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&models.BookClubActivity{}, &models.BookClubPost{}, &models.BookClubSegment{}, &models.BookClubMember{},
+		} {
+			if err := tx.Where("club_id = ?", club.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&club).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Kulüp silinemedi",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Kulüp silindi",
+	})
+}
+
+// RequestBookClubMembership asks to join a club. Accepting an open invitation joins directly.
+func RequestBookClubMembership(c *fiber.Ctx) error {
+	clubID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	if userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var club models.BookClub
+	if err := database.DB.First(&club, clubID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kulüp bulunamadı",
+		})
+	}
+
+	member, exists := findClubMembership(club.ID, userID)
+	if exists {
+		switch member.Status {
+		case models.ClubMemberAccepted:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Zaten bu kulübün üyesisiniz",
+			})
+		case models.ClubMemberRequested:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Katılma isteğiniz zaten gönderildi",
+			})
+		case models.ClubMemberInvited:
+			if err := acceptClubMembership(&member); err != nil {
+				return acceptClubMembershipError(c, err)
+			}
+			notifyClubRequests(userID, "book_club_request_update", nil)
+			notifyClubRequests(club.OwnerID, "book_club_request_accepted", map[string]interface{}{
+				"club_id":  club.ID,
+				"username": getUsernameByID(userID),
+			})
+			return c.JSON(fiber.Map{
+				"message": "Kulübe katıldınız",
+				"member":  member,
+			})
+		}
+	}
+
+	member = models.BookClubMember{ClubID: club.ID, AdminID: userID, Status: models.ClubMemberRequested}
+	if err := database.DB.Create(&member).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Katılma isteği gönderilemedi",
+		})
+	}
+
+	notifyClubRequests(club.OwnerID, "book_club_request_received", map[string]interface{}{
+		"club_id":  club.ID,
+		"username": getUsernameByID(userID),
+	})
+
+	return c.JSON(fiber.Map{
+		"message": "Katılma isteği gönderildi",
+		"member":  member,
+	})
+}
+
+// InviteToBookClub invites a user by username (owner). A pending request from that user is accepted.
+func InviteToBookClub(c *fiber.Ctx) error {
+	club, ok, err := loadMemberClub(c)
+	if !ok {
+		return err
+	}
+	userID := GetUserId(c)
+	if club.OwnerID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Yalnızca kulüp sahibi davet gönderebilir",
+		})
+	}
+
+	var data struct {
+		Username string `json:"username"`
+	}
+	if err := c.BodyParser(&data); err != nil || data.Username == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Kullanıcı adı gerekli",
+		})
+	}
+	var invitee models.Admin
+	if err := database.DB.Select("id", "username").Where("username = ?", data.Username).First(&invitee).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kullanıcı bulunamadı",
+		})
+	}
+
+	member, exists := findClubMembership(club.ID, invitee.ID)
+	if exists {
+		switch member.Status {
+		case models.ClubMemberAccepted:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Kullanıcı zaten üye",
+			})
+		case models.ClubMemberInvited:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Davet zaten gönderildi",
+			})
+		case models.ClubMemberRequested:
+			if err := acceptClubMembership(&member); err != nil {
+				return acceptClubMembershipError(c, err)
+			}
+			notifyClubRequests(userID, "book_club_request_update", nil)
+			notifyClubRequests(invitee.ID, "book_club_request_accepted", map[string]interface{}{
+				"club_id": club.ID,
+				"name":    club.Name,
+			})
+			return c.JSON(fiber.Map{
+				"message": "Katılma isteği kabul edildi",
+				"member":  member,
+			})
+		}
+	}
+
+	member = models.BookClubMember{ClubID: club.ID, AdminID: invitee.ID, Status: models.ClubMemberInvited, InvitedBy: &userID}
+	if err := database.DB.Create(&member).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Davet gönderilemedi",
+		})
+	}
+
+	notifyClubRequests(invitee.ID, "book_club_invite_received", map[string]interface{}{
+		"club_id":  club.ID,
+		"name":     club.Name,
+		"username": getUsernameByID(userID),
+	})
+
+	return c.JSON(fiber.Map{
+		"message": "Davet gönderildi",
+		"member":  member,
+	})
+}
+
+// GetBookClubRequests lists invitations to the session user and requests to clubs they own
+func GetBookClubRequests(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+
+	invitations := []models.BookClubMember{}
+	database.DB.Where("admin_id = ? AND status = ?", userID, models.ClubMemberInvited).
+		Preload("Club").
+		Order("created_at DESC").
+		Find(&invitations)
+
+	requests := []models.BookClubMember{}
+	database.DB.Joins("JOIN book_clubs ON book_clubs.id = book_club_members.club_id").
+		Where("book_clubs.owner_id = ? AND book_club_members.status = ?", userID, models.ClubMemberRequested).
+		Preload("Club").
+		Preload("Admin", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "profile_image")
+		}).
+		Order("book_club_members.created_at DESC").
+		Find(&requests)
+
+	return c.JSON(fiber.Map{
+		"invitations": invitations,
+		"requests":    requests,
+		"count":       len(invitations) + len(requests),
+	})
+}
+
+// AcceptBookClubMember accepts an invitation (invitee) or a join request (owner)
+func AcceptBookClubMember(c *fiber.Ctx) error {
+	memberID, _ := strconv.Atoi(c.Params("member_id"))
+	userID := GetUserId(c)
+
+	var member models.BookClubMember
+	if err := database.DB.Preload("Club").First(&member, memberID).Error; err != nil || member.Club == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "İstek bulunamadı",
+		})
+	}
+	club := *member.Club
+	member.Club = nil
+
+	allowed := (member.Status == models.ClubMemberInvited && member.AdminID == userID) ||
+		(member.Status == models.ClubMemberRequested && club.OwnerID == userID)
+	if !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu isteği kabul edemezsiniz",
+		})
+	}
+	if err := acceptClubMembership(&member); err != nil {
+		return acceptClubMembershipError(c, err)
+	}
+
+	otherID := club.OwnerID
+	if userID == club.OwnerID {
+		otherID = member.AdminID
+	}
+	notifyClubRequests(userID, "book_club_request_update", nil)
+	notifyClubRequests(otherID, "book_club_request_accepted", map[string]interface{}{
+		"club_id":  club.ID,
+		"name":     club.Name,
+		"username": getUsernameByID(userID),
+	})
+
+	return c.JSON(fiber.Map{
+		"message": "Kulübe katılım onaylandı",
+		"member":  member,
+	})
+}
+
+// DeleteBookClubMember declines an invitation, cancels or rejects a request, or removes a
+// member (owner). The owner cannot be removed; they delete the club instead.
+func DeleteBookClubMember(c *fiber.Ctx) error {
+	memberID, _ := strconv.Atoi(c.Params("member_id"))
+	userID := GetUserId(c)
+
+	var member models.BookClubMember
+	if err := database.DB.Preload("Club").First(&member, memberID).Error; err != nil || member.Club == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Üyelik bulunamadı",
+		})
+	}
+	club := *member.Club
+	if member.AdminID == club.OwnerID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Kulüp sahibi kulüpten ayrılamaz",
+		})
+	}
+	if member.AdminID != userID && club.OwnerID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu üyeliği silme yetkiniz yok",
+		})
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.BookClubMember{}, member.ID).Error; err != nil {
+			return err
+		}
+		if member.Status != models.ClubMemberAccepted {
+			return nil
+		}
+		return recordClubActivity(tx, models.BookClubActivity{
+			ClubID:  club.ID,
+			AdminID: member.AdminID,
+			Type:    models.ClubActivityLeft,
+		})
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Üyelik silinemedi",
+		})
+	}
+
+	notifyClubRequests(member.AdminID, "book_club_request_update", map[string]interface{}{
+		"club_id": club.ID,
+	})
+	notifyClubRequests(club.OwnerID, "book_club_request_update", map[string]interface{}{
+		"club_id": club.ID,
+	})
+
+	return c.JSON(fiber.Map{
+		"message": "Üyelik silindi",
+	})
+}
+
+// SetBookClubBook sets the club's current book and its weekly schedule (owner). The schedule
+// is either explicit page ranges ("segments": [{"start_page", "end_page"}]) or "weeks" of
+// equal length, starting on "starts_on" (YYYY-MM-DD, default today).
+func SetBookClubBook(c *fiber.Ctx) error {
+	club, ok, err := loadMemberClub(c)
+	if !ok {
+		return err
+	}
+	userID := GetUserId(c)
+	roleID, _ := helpers.GetUserRole(c)
+	if club.OwnerID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Kitabı yalnızca kulüp sahibi seçebilir",
+		})
+	}
+
+	var data struct {
+		BookID   uint   `json:"book_id"`
+		StartsOn string `json:"starts_on"`
+		Weeks    int    `json:"weeks"`
+		Segments []struct {
+			StartPage int `json:"start_page"`
+			EndPage   int `json:"end_page"`
+		} `json:"segments"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	book, err := findVisibleBook(data.BookID, roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kitap bulunamadı",
+		})
+	}
+
+	startsOn := helpers.AppNow()
+	startsOn = time.Date(startsOn.Year(), startsOn.Month(), startsOn.Day(), 0, 0, 0, 0, helpers.AppLocation())
+	if data.StartsOn != "" {
+		if startsOn, err = helpers.ParseAppDate(data.StartsOn); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Geçersiz tarih (YYYY-MM-DD)",
+			})
+		}
+	}
+	ranges := make([][2]int, len(data.Segments))
+	for i, segment := range data.Segments {
+		ranges[i] = [2]int{segment.StartPage, segment.EndPage}
+	}
+	segments, err := buildClubSchedule(club, book, startsOn, data.Weeks, ranges)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	// Posts stay with the segments they were written in, so a schedule for a book the club
+	// already discussed can only be replaced while it has no posts
+	var posts int64
+	database.DB.Model(&models.BookClubPost{}).
+		Joins("JOIN book_club_segments ON book_club_segments.id = book_club_posts.segment_id").
+		Where("book_club_segments.club_id = ? AND book_club_segments.book_id = ?", club.ID, book.ID).
+		Count(&posts)
+	if posts > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"message": "Bu kitabın programında tartışmalar var, program değiştirilemez",
+		})
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("club_id = ? AND book_id = ?", club.ID, book.ID).Delete(&models.BookClubSegment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&segments).Error; err != nil {
+			return err
+		}
+		club.CurrentBookID = &book.ID
+		club.StartsOn = &startsOn
+		if err := tx.Save(&club).Error; err != nil {
+			return err
+		}
+		return recordClubActivity(tx, models.BookClubActivity{
+			ClubID:  club.ID,
+			AdminID: userID,
+			Type:    models.ClubActivityBookChanged,
+			BookID:  &book.ID,
+		})
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Program kaydedilemedi",
+		})
+	}
+
+	for _, memberID := range clubMemberIDs(club.ID) {
+		if memberID == userID {
+			continue
+		}
+		fmt.Printf("[WebSocket] Sending book_club_book_changed to user %d\n", memberID)
+		ws.GlobalHub.SendToUser(memberID, "book_club_book_changed", map[string]interface{}{
+			"club_id":   club.ID,
+			"name":      club.Name,
+			"book_id":   book.ID,
+			"book_name": book.Name,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"club":     club,
+		"segments": segments,
+	})
+}
+
+// GetBookClubSchedule returns the schedule of the club's current book, or ?book_id= of a
+// previous one, with which weeks the session user has unlocked
+func GetBookClubSchedule(c *fiber.Ctx) error {
+	club, ok, err := loadMemberClub(c)
+	if !ok {
+		return err
+	}
+	userID := GetUserId(c)
+
+	bookID, _ := strconv.Atoi(c.Query("book_id"))
+	if bookID == 0 && club.CurrentBookID != nil {
+		bookID = int(*club.CurrentBookID)
+	}
+
+	var segments []models.BookClubSegment
+	database.DB.Where("club_id = ? AND book_id = ?", club.ID, bookID).Order("week ASC").Find(&segments)
+
+	var counts []struct {
+		SegmentID uint
+		Count     int64
+	}
+	database.DB.Model(&models.BookClubPost{}).
+		Select("segment_id, COUNT(*) AS count").
+		Where("club_id = ?", club.ID).
+		Group("segment_id").
+		Scan(&counts)
+	countBySegment := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countBySegment[count.SegmentID] = count.Count
+	}
+
+	page := clubReadingPage(userID, uint(bookID))
+	now := helpers.AppNow()
+	weeks := make([]BookClubScheduleWeek, len(segments))
+	for i, segment := range segments {
+		segment.PostCount = countBySegment[segment.ID]
+		weeks[i] = BookClubScheduleWeek{
+			BookClubSegment: segment,
+			Current:         !now.Before(segment.StartsOn) && now.Before(segment.StartsOn.AddDate(0, 0, 7)),
+			Unlocked:        page >= segment.EndPage,
+		}
+	}
+
+	myPage := page
+	if page == math.MaxInt32 {
+		myPage = -1
+	}
+	return c.JSON(fiber.Map{
+		"book_id":  bookID,
+		"my_page":  myPage, // -1 when the book is finished
+		"schedule": weeks,
+	})
+}
+
+// AddBookClubPost posts to the discussion of a schedule segment. "page" is the furthest page
+// the post discusses and defaults to the end of the segment.
+func AddBookClubPost(c *fiber.Ctx) error {
+	segmentID, _ := strconv.Atoi(c.Params("segment_id"))
+	userID := GetUserId(c)
+
+	var segment models.BookClubSegment
+	if err := database.DB.First(&segment, segmentID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Program haftası bulunamadı",
+		})
+	}
+	if !isClubMember(segment.ClubID, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu kulübün yalnızca üyeleri yazabilir",
+		})
+	}
+
+	var data struct {
+		Content  string `json:"content"`
+		Page     *int   `json:"page"`
+		ParentID *uint  `json:"parent_id"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	content := strings.TrimSpace(security.NewSanitizer().SanitizePlainText(data.Content))
+	if content == "" || len([]rune(content)) > maxClubPostLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": fmt.Sprintf("Mesaj 1-%d karakter olmalı", maxClubPostLength),
+		})
+	}
+	if security.NewContentFilter().Check(content).Action == security.FilterReject {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"message": "Mesaj uygunsuz ifadeler içeriyor",
+		})
+	}
+
+	page := segment.EndPage
+	if data.Page != nil {
+		if *data.Page < 1 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Geçersiz sayfa",
+			})
+		}
+		page = *data.Page
+	}
+	if data.ParentID != nil {
+		var parent models.BookClubPost
+		if err := database.DB.Where("id = ? AND segment_id = ?", *data.ParentID, segment.ID).First(&parent).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Yanıtlanan mesaj bulunamadı",
+			})
+		}
+		// Replies stay one level deep under the thread's first post
+		if parent.ParentID != nil {
+			data.ParentID = parent.ParentID
+		}
+		// A reply reveals at least as much as the post it answers
+		if parent.Page != nil && *parent.Page > page {
+			page = *parent.Page
+		}
+	}
+
+	post := models.BookClubPost{
+		ClubID:    segment.ClubID,
+		SegmentID: segment.ID,
+		AdminID:   userID,
+		ParentID:  data.ParentID,
+		Content:   content,
+		Page:      &page,
+	}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&post).Error; err != nil {
+			return err
+		}
+		return recordClubActivity(tx, models.BookClubActivity{
+			ClubID:    segment.ClubID,
+			AdminID:   userID,
+			Type:      models.ClubActivityPosted,
+			BookID:    &segment.BookID,
+			SegmentID: &segment.ID,
+			PostID:    &post.ID,
+		})
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Mesaj gönderilemedi",
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(post)
+}
+
+// GetBookClubPosts returns the discussion of a segment, oldest first. Posts about pages
+// beyond the session user's progress have their content withheld (spoiler_hidden) unless
+// ?reveal=true.
+func GetBookClubPosts(c *fiber.Ctx) error {
+	segmentID, _ := strconv.Atoi(c.Params("segment_id"))
+	userID := GetUserId(c)
+	roleID, _ := helpers.GetUserRole(c)
+
+	var segment models.BookClubSegment
+	if err := database.DB.First(&segment, segmentID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Program haftası bulunamadı",
+		})
+	}
+	if !isClubMember(segment.ClubID, userID) && !isModerator(roleID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu kulübün yalnızca üyeleri görebilir",
+		})
+	}
+
+	posts := []models.BookClubPost{}
+	database.DB.Where("segment_id = ?", segment.ID).
+		Preload("Admin", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "profile_image")
+		}).
+		Order("created_at ASC").
+		Find(&posts)
+
+	page := clubReadingPage(userID, segment.BookID)
+	reveal := c.Query("reveal") == "true"
+	hidden := 0
+	for i := range posts {
+		if reveal || posts[i].AdminID == userID || posts[i].Page == nil || *posts[i].Page <= page {
+			continue
+		}
+		posts[i].SpoilerHidden = true
+		posts[i].Content = ""
+		hidden++
+	}
+
+	return c.JSON(fiber.Map{
+		"segment": segment,
+		"posts":   posts,
+		"hidden":  hidden,
+	})
+}
+
+// DeleteBookClubPost deletes a post and its replies (author, club owner or admin)
+func DeleteBookClubPost(c *fiber.Ctx) error {
+	postID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	roleID, _ := helpers.GetUserRole(c)
+
+	var post models.BookClubPost
+	if err := database.DB.First(&post, postID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Mesaj bulunamadı",
+		})
+	}
+	var club models.BookClub
+	database.DB.Select("id", "owner_id").First(&club, post.ClubID)
+	if post.AdminID != userID && club.OwnerID != userID && !isModerator(roleID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu mesajı silme yetkiniz yok",
+		})
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		tx.Model(&models.BookClubPost{}).Where("id = ? OR parent_id = ?", post.ID, post.ID).Pluck("id", &ids)
+		if err := tx.Where("post_id IN ?", ids).Delete(&models.BookClubActivity{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&models.BookClubPost{}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Mesaj silinemedi",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Mesaj silindi",
+	})
+}
+
+// GetBookClubActivity returns the latest activity of a club (members only)
+func GetBookClubActivity(c *fiber.Ctx) error {
+	club, ok, err := loadMemberClub(c)
+	if !ok {
+		return err
+	}
+
+	activity := []models.BookClubActivity{}
+	database.DB.Where("club_id = ?", club.ID).
+		Preload("Admin", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "profile_image")
+		}).
+		Order("created_at DESC").
+		Limit(clubActivityFeedLength).
+		Find(&activity)
+
+	return c.JSON(activity)
+}
@@ -14,10 +14,19 @@ import (
 
 // book's community: 1-private, 2-public
 func applyCommunityFilterForBook(db *gorm.DB, roleID uint) *gorm.DB {
-	if roleID == 1 || roleID == 2 {
-		return db
+	return applyCommunityLevelFilter(db, communityLevelForRole(roleID))
+}
+
+// bookSortOrder maps ?sort= to an ORDER BY for book listings: rating (highest average,
+// then most ratings), ratings (most rated) or the default newest first
+func bookSortOrder(sort string) string {
+	switch sort {
+	case "rating":
+		return "rating_average DESC, rating_count DESC, id DESC"
+	case "ratings":
+		return "rating_count DESC, rating_average DESC, id DESC"
 	}
-	return db.Where("community = ?", 2)
+	return "created_at DESC"
 }
 
 func CreateBook(c *fiber.Ctx) error {
@@ -26,6 +35,12 @@ func CreateBook(c *fiber.Ctx) error {
 		return err
 	}
 	book.IsDeleted = false
+	clearBookRatings(&book)
+	if err := validateBookMetadata(&book); err != nil {
+		return bookMetadataError(c, err)
+	}
+	genres, series, contributors := book.Genres, book.Series, book.Contributors
+	book.Genres, book.Series, book.Work, book.Contributors = nil, nil, nil, nil
 	x := map[rune]rune{
 		' ':  '-',
 		'ç':  'c',
@@ -47,9 +62,32 @@ func CreateBook(c *fiber.Ctx) error {
 	slug := replaceChars(book.Name, x)
 	book.CreatedAt = time.Now().Format("02-01-2006")
 	book.Slug = strings.ToLower(slug)
-	database.DB.Create(&book)
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkDuplicateISBN(tx, &book); err != nil {
+			return err
+		}
+		if err := resolveSeries(tx, &book, series); err != nil {
+			return err
+		}
+		if err := ensureBookWork(tx, &book); err != nil {
+			return err
+		}
+		resolved, err := resolveGenres(tx, genres)
+		if err != nil {
+			return err
+		}
+		book.Genres = resolved
+		if err := tx.Create(&book).Error; err != nil {
+			return err
+		}
+		return replaceContributors(tx, "book_id", book.ID, book.AuthorID, contributors)
+	})
+	if err != nil {
+		return bookMetadataError(c, err)
+	}
 
 	userID := GetUserId(c)
+	go notifyAuthorFollowers(book.AuthorID, book.Community, models.NotificationAuthorBook, book.ID, book.Name, book.Slug, userID)
 	roleID, err := helpers.GetUserRole(c)
 	if err != nil {
 		return err
@@ -85,8 +123,9 @@ func GetBooksPaginated(c *fiber.Ctx) error {
 	// Apply search filter if provided
 	if search != "" {
 		searchPattern := "%" + search + "%"
-		baseQuery = baseQuery.Where("name ILIKE ? OR author ILIKE ?", searchPattern, searchPattern)
+		baseQuery = baseQuery.Where("name ILIKE ? OR author ILIKE ? OR id IN ("+bookContributorSearchSQL+")", searchPattern, searchPattern, searchPattern)
 	}
+	baseQuery = applyBookMetadataFilters(baseQuery, c)
 
 	// Get total count
 	var total int64
@@ -100,27 +139,19 @@ func GetBooksPaginated(c *fiber.Ctx) error {
 	// Apply search filter
 	if search != "" {
 		searchPattern := "%" + search + "%"
-		query = query.Where("name ILIKE ? OR author ILIKE ?", searchPattern, searchPattern)
+		query = query.Where("name ILIKE ? OR author ILIKE ? OR id IN ("+bookContributorSearchSQL+")", searchPattern, searchPattern, searchPattern)
 	}
+	query = applyBookMetadataFilters(query, c)
 
-	// Preload comments with Admin data
-	query = query.Preload("Comments", "is_deleted = ?", false).
-		Preload("Comments.Admin").
-		Preload("AuthorData")
+	query = preloadBookMetadata(query.Preload("AuthorData"))
 
 	query.Offset(params.Offset).
 		Limit(params.Limit).
-		Order("created_at DESC").
+		Order(bookSortOrder(c.Query("sort"))).
 		Find(&books)
 
-	// Filter comments by friendship for each book
-	for i := range books {
-		books[i].Comments = filterCommentsByFriendship(books[i].Comments, userID, roleID)
-		// Ensure Comments is never nil
-		if books[i].Comments == nil {
-			books[i].Comments = []models.Comment{}
-		}
-	}
+	// Load comment threads visible to the user for all books at once
+	attachBookCommentThreads(books, userID, roleID)
 
 	// Create paginated response
 	response := helpers.CreatePaginationResponse(books, total, params.Offset, params.Limit)
@@ -141,30 +172,12 @@ func GetBook(c *fiber.Ctx) error {
 	var book models.Book
 	query := database.DB.Where("slug = ?", slug)
 	query = applyCommunityFilterForBook(query, roleID)
-	query.Preload("AuthorData").
-		Preload("Comments", "is_deleted = ?", false).
-		Preload("Comments.Admin").
-		First(&book)
-
-	// Debug: Log before filtering
-	fmt.Printf("[DEBUG GetBook] userID=%d, roleID=%d, slug=%s, comments_before=%d\n", userID, roleID, slug, len(book.Comments))
-	for i, comment := range book.Comments {
-		fmt.Printf("[DEBUG GetBook] Comment #%d: ID=%d, AdminID=%d, Admin=%s\n", i, comment.ID, comment.AdminID, comment.Admin.Username)
-	}
+	preloadContributors(preloadBookMetadata(query.Preload("AuthorData"))).First(&book)
 
-	// Filter comments by friendship
-	book.Comments = filterCommentsByFriendship(book.Comments, userID, roleID)
-
-	// Ensure Comments is never nil (should be empty array instead)
-	if book.Comments == nil {
-		book.Comments = []models.Comment{}
-	}
-
-	// Debug: Log after filtering
-	fmt.Printf("[DEBUG GetBook] comments_after=%d\n", len(book.Comments))
-	for i, comment := range book.Comments {
-		fmt.Printf("[DEBUG GetBook] Filtered Comment #%d: ID=%d, AdminID=%d, Admin=%s\n", i, comment.ID, comment.AdminID, comment.Admin.Username)
-	}
+	// Load comment threads visible to the user
+	books := []models.Book{book}
+	attachBookCommentThreads(books, userID, roleID)
+	book = books[0]
 
 	return c.JSON(book)
 }
@@ -173,15 +186,10 @@ func getBooks(roleID uint, userID uint) []models.Book {
 	var books []models.Book
 	query := database.DB.Where("is_deleted = ?", false)
 	query = applyCommunityFilterForBook(query, roleID)
-	query.Preload("AuthorData").
-		Preload("Comments", "is_deleted = ?", false).
-		Preload("Comments.Admin").
-		Find(&books)
+	preloadBookMetadata(query.Preload("AuthorData")).Find(&books)
 
-	// Filter comments by friendship for each book
-	for i := range books {
-		books[i].Comments = filterCommentsByFriendship(books[i].Comments, userID, roleID)
-	}
+	// Load comment threads visible to the user for all books at once
+	attachBookCommentThreads(books, userID, roleID)
 
 	return books
 }
@@ -196,7 +204,7 @@ func GetBookById(c *fiber.Ctx) error {
 	var book models.Book
 	query := database.DB.Table("books").Where("id", id)
 	query = applyCommunityFilterForBook(query, roleID)
-	result := query.Preload("AuthorData").First(&book)
+	result := preloadContributors(preloadBookMetadata(query.Preload("AuthorData"))).First(&book)
 
 	if result.Error != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -264,11 +272,84 @@ func UpdateBook(c *fiber.Ctx) error {
 	if updateData.Community > 0 {
 		book.Community = updateData.Community
 	}
+	if updateData.ISBN10 != "" {
+		book.ISBN10 = updateData.ISBN10
+		// A new ISBN-10 without an ISBN-13 derives the matching ISBN-13 again
+		if updateData.ISBN13 == "" {
+			book.ISBN13 = ""
+		}
+	}
+	if updateData.ISBN13 != "" {
+		book.ISBN13 = updateData.ISBN13
+	}
+	if updateData.Publisher != "" {
+		book.Publisher = updateData.Publisher
+	}
+	if updateData.PublicationYear != nil {
+		book.PublicationYear = updateData.PublicationYear
+	}
+	if updateData.Language != "" {
+		book.Language = updateData.Language
+	}
+	if updateData.Translator != "" {
+		book.Translator = updateData.Translator
+	}
+	if updateData.WorkID != nil {
+		book.WorkID = updateData.WorkID
+	}
+	if updateData.SeriesID != nil {
+		book.SeriesID = updateData.SeriesID
+	}
+	if updateData.SeriesPosition != nil {
+		book.SeriesPosition = updateData.SeriesPosition
+	}
+	if updateData.FirstPublishedDate != nil {
+		book.FirstPublishedDate = updateData.FirstPublishedDate
+	}
+	if err := validateBookMetadata(&book); err != nil {
+		return bookMetadataError(c, err)
+	}
 
-	if err := database.DB.Save(&book).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update book",
-		})
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkDuplicateISBN(tx, &book); err != nil {
+			return err
+		}
+		series := updateData.Series
+		if series == nil && updateData.SeriesID != nil {
+			series = &models.Series{ID: *updateData.SeriesID}
+		}
+		if err := resolveSeries(tx, &book, series); err != nil {
+			return err
+		}
+		if err := ensureBookWork(tx, &book); err != nil {
+			return err
+		}
+		// Rating aggregates are only written by recalculateBookRatings
+		if err := tx.Omit(bookRatingColumns...).Save(&book).Error; err != nil {
+			return err
+		}
+		// Contributors are replaced only when the request sends them
+		if updateData.Contributors != nil {
+			if err := replaceContributors(tx, "book_id", book.ID, book.AuthorID, updateData.Contributors); err != nil {
+				return err
+			}
+		} else if updateData.AuthorID != nil {
+			if err := syncPrimaryContributor(tx, "book_id", book.ID, book.AuthorID); err != nil {
+				return err
+			}
+		}
+		// Genres are replaced only when the request sends them
+		if updateData.Genres == nil {
+			return nil
+		}
+		genres, err := resolveGenres(tx, updateData.Genres)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&book).Association("Genres").Replace(genres)
+	})
+	if err != nil {
+		return bookMetadataError(c, err)
 	}
 
 	userID := GetUserId(c)
@@ -296,37 +377,3 @@ func DeleteBook(c *fiber.Ctx) error {
 	}
 	return c.JSON(getBooks(roleID, userID))
 }
-
-// filterCommentsByFriendship filters comments based on friendship relationships
-func filterCommentsByFriendship(comments []models.Comment, userID uint, roleID uint) []models.Comment {
-	fmt.Printf("[DEBUG filterComments] userID=%d, roleID=%d, total_comments=%d\n", userID, roleID, len(comments))
-
-	// Admin can see all comments
-	if roleID == 1 {
-		fmt.Println("[DEBUG filterComments] User is admin, returning all comments")
-		return comments
-	}
-
-	// Get friend IDs (includes self)
-	friendIDs := GetFriendIDs(userID)
-	fmt.Printf("[DEBUG filterComments] friendIDs=%v\n", friendIDs)
-
-	// Filter comments to only include those from friends
-	filteredComments := []models.Comment{}
-	for _, comment := range comments {
-		isFriend := false
-		for _, friendID := range friendIDs {
-			if comment.AdminID == friendID {
-				isFriend = true
-				break
-			}
-		}
-		fmt.Printf("[DEBUG filterComments] Comment ID=%d, AdminID=%d, isFriend=%v\n", comment.ID, comment.AdminID, isFriend)
-		if isFriend {
-			filteredComments = append(filteredComments, comment)
-		}
-	}
-
-	fmt.Printf("[DEBUG filterComments] Filtered result: %d comments\n", len(filteredComments))
-	return filteredComments
-}
@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBookMetadataLength = 255
+	minPublicationYear    = 1450
+)
+
+// errDuplicateISBN is returned when another book already uses the ISBN-13
+var errDuplicateISBN = errors.New("another book already has this ISBN")
+
+// validateBookMetadata normalizes and validates the edition fields of a book in place. When
+// only an ISBN-10 is given the ISBN-13 is derived from it; when both are given they must
+// belong to the same edition.
+func validateBookMetadata(book *models.Book) error {
+	validator := security.NewValidator()
+	sanitizer := security.NewSanitizer()
+
+	isbn10, err := validator.ValidateISBN("isbn10", book.ISBN10, 10)
+	if err != nil {
+		return err
+	}
+	isbn13, err := validator.ValidateISBN("isbn13", book.ISBN13, 13)
+	if err != nil {
+		return err
+	}
+	if isbn10 != "" {
+		if isbn13 == "" {
+			isbn13 = security.ISBN10To13(isbn10)
+		} else if security.ISBN10To13(isbn10) != isbn13 {
+			return &security.ValidationError{Field: "isbn10", Message: "does not match isbn13"}
+		}
+	}
+	book.ISBN10 = isbn10
+	book.ISBN13 = isbn13
+
+	if book.PublicationYear != nil {
+		maxYear := helpers.AppNow().Year() + 1
+		if *book.PublicationYear < minPublicationYear || *book.PublicationYear > maxYear {
+			return &security.ValidationError{
+				Field:   "publication_year",
+				Message: fmt.Sprintf("must be between %d and %d", minPublicationYear, maxYear),
+			}
+		}
+	}
+
+	book.Language = security.NormalizeLanguageCode(book.Language)
+	if err := validator.ValidateLanguageCode("language", book.Language); err != nil {
+		return err
+	}
+
+	book.Publisher = helpers.TruncateRunes(sanitizer.SanitizeString(sanitizer.SanitizePlainText(book.Publisher), 0), maxBookMetadataLength)
+	book.Translator = helpers.TruncateRunes(sanitizer.SanitizeString(sanitizer.SanitizePlainText(book.Translator), 0), maxBookMetadataLength)
+
+	if book.SeriesPosition != nil && *book.SeriesPosition <= 0 {
+		return &security.ValidationError{Field: "series_position", Message: "must be greater than 0"}
+	}
+
+	book.FirstPublishedDate, err = validator.ValidateDate("first_published_date", book.FirstPublishedDate)
+	return err
+}
+
+// checkDuplicateISBN rejects an ISBN-13 that another non-deleted book already uses
+func checkDuplicateISBN(tx *gorm.DB, book *models.Book) error {
+	if book.ISBN13 == "" {
+		return nil
+	}
+	var count int64
+	tx.Model(&models.Book{}).
+		Where("isbn13 = ? AND is_deleted = ? AND id <> ?", book.ISBN13, false, book.ID).
+		Count(&count)
+	if count > 0 {
+		return errDuplicateISBN
+	}
+	return nil
+}
+
+// resolveGenres maps the genres sent with a book to stored genres. A genre is matched by id,
+// otherwise by the slug of its name; unknown names are created.
+func resolveGenres(tx *gorm.DB, input []models.Genre) ([]models.Genre, error) {
+	genres := []models.Genre{}
+	seen := map[uint]bool{}
+	for _, item := range input {
+		var genre models.Genre
+		if item.ID != 0 {
+			if err := tx.First(&genre, item.ID).Error; err != nil {
+				return nil, &security.ValidationError{Field: "genres", Message: fmt.Sprintf("genre %d not found", item.ID)}
+			}
+		} else {
+			name := helpers.TruncateRunes(security.NewSanitizer().SanitizeString(item.Name, 0), 64)
+			slug := helpers.Slugify(name)
+			if slug == "" {
+				continue
+			}
+			if err := tx.Where(models.Genre{Slug: slug}).Attrs(models.Genre{Name: name}).FirstOrCreate(&genre).Error; err != nil {
+				return nil, err
+			}
+		}
+		if !seen[genre.ID] {
+			seen[genre.ID] = true
+			genres = append(genres, genre)
+		}
+	}
+	return genres, nil
+}
+
+// resolveSeries sets SeriesID from the series sent with a book, matching an existing series
+// by id or by the slug of its name and creating it otherwise
+func resolveSeries(tx *gorm.DB, book *models.Book, input *models.Series) error {
+	if input == nil {
+		return nil
+	}
+	var series models.Series
+	if input.ID != 0 {
+		if err := tx.First(&series, input.ID).Error; err != nil {
+			return &security.ValidationError{Field: "series", Message: "series not found"}
+		}
+	} else {
+		name := helpers.TruncateRunes(security.NewSanitizer().SanitizeString(input.Name, 0), maxBookMetadataLength)
+		slug := helpers.Slugify(name)
+		if slug == "" {
+			return &security.ValidationError{Field: "series", Message: "name is required"}
+		}
+		if err := tx.Where(models.Series{Slug: slug}).Attrs(models.Series{Name: name}).FirstOrCreate(&series).Error; err != nil {
+			return err
+		}
+	}
+	book.SeriesID = &series.ID
+	return nil
+}
+
+// ensureBookWork checks the work a book is an edition of, or starts a new work for it
+func ensureBookWork(tx *gorm.DB, book *models.Book) error {
+	if book.WorkID != nil {
+		var work models.Work
+		if err := tx.Select("id").First(&work, *book.WorkID).Error; err != nil {
+			return &security.ValidationError{Field: "work_id", Message: "work not found"}
+		}
+		return nil
+	}
+	work := models.Work{
+		Title:              book.Name,
+		AuthorID:           book.AuthorID,
+		OriginalLanguage:   book.Language,
+		FirstPublishedYear: book.PublicationYear,
+	}
+	if err := tx.Create(&work).Error; err != nil {
+		return err
+	}
+	book.WorkID = &work.ID
+	return nil
+}
+
+// bookMetadataError writes the response for an error from the metadata helpers
+func bookMetadataError(c *fiber.Ctx, err error) error {
+	var validationErr *security.ValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": validationErr.Error(),
+		})
+	}
+	if errors.Is(err, errDuplicateISBN) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to save book",
+	})
+}
+
+// preloadBookMetadata loads the genres and series shown with a book
+func preloadBookMetadata(query *gorm.DB) *gorm.DB {
+	return query.Preload("Genres").Preload("Series")
+}
+
+// applyBookMetadataFilters narrows a books query by ?isbn=, ?publisher=, ?language=,
+// ?translator=, ?year= / ?year_from= / ?year_to=, ?genre= (slugs, comma separated, any of),
+// ?series= (slug) and ?work_id=
+func applyBookMetadataFilters(query *gorm.DB, c *fiber.Ctx) *gorm.DB {
+	sanitizer := security.NewSanitizer()
+
+	if isbn := security.NormalizeISBN(c.Query("isbn")); isbn != "" {
+		query = query.Where("(books.isbn13 = ? OR books.isbn10 = ?)", isbn, isbn)
+	}
+	if publisher := sanitizer.SanitizeSQLLike(c.Query("publisher")); publisher != "" {
+		query = query.Where("books.publisher ILIKE ?", "%"+publisher+"%")
+	}
+	if translator := sanitizer.SanitizeSQLLike(c.Query("translator")); translator != "" {
+		query = query.Where("books.translator ILIKE ?", "%"+translator+"%")
+	}
+	if language := security.NormalizeLanguageCode(c.Query("language")); language != "" {
+		query = query.Where("books.language = ?", language)
+	}
+	if year, err := strconv.Atoi(c.Query("year")); err == nil {
+		query = query.Where("books.publication_year = ?", year)
+	}
+	if from, err := strconv.Atoi(c.Query("year_from")); err == nil {
+		query = query.Where("books.publication_year >= ?", from)
+	}
+	if to, err := strconv.Atoi(c.Query("year_to")); err == nil {
+		query = query.Where("books.publication_year <= ?", to)
+	}
+	if genre := c.Query("genre"); genre != "" {
+		query = query.Where("books.id IN (?)", database.DB.Table("book_genres").
+			Select("book_genres.book_id").
+			Joins("JOIN genres ON genres.id = book_genres.genre_id").
+			Where("genres.slug IN ?", strings.Split(genre, ",")))
+	}
+	if series := c.Query("series"); series != "" {
+		query = query.Where("books.series_id IN (?)", database.DB.Model(&models.Series{}).Select("id").Where("slug = ?", series))
+	}
+	if workID, err := strconv.Atoi(c.Query("work_id")); err == nil {
+		query = query.Where("books.work_id = ?", workID)
+	}
+	return query
+}
+
+// GetGenres lists all genres with the number of books the user can see in each
+func GetGenres(c *fiber.Ctx) error {
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	genres := []models.Genre{}
+	database.DB.Order("name ASC").Find(&genres)
+
+	var counts []struct {
+		GenreID uint
+		Count   int64
+	}
+	query := database.DB.Table("book_genres").
+		Select("book_genres.genre_id, COUNT(*) AS count").
+		Joins("JOIN books ON books.id = book_genres.book_id").
+		Where("books.is_deleted = ?", false)
+	if roleID != 1 && roleID != 2 {
+		query = query.Where("books.community = ?", 2)
+	}
+	query.Group("book_genres.genre_id").Scan(&counts)
+
+	byGenre := map[uint]int64{}
+	for _, count := range counts {
+		byGenre[count.GenreID] = count.Count
+	}
+	for i := range genres {
+		genres[i].BookCount = byGenre[genres[i].ID]
+	}
+
+	return c.JSON(genres)
+}
+
+// GetSeriesList lists all series, ?search= matching the name
+func GetSeriesList(c *fiber.Ctx) error {
+	series := []models.Series{}
+	query := database.DB.Order("name ASC")
+	if search := security.NewSanitizer().SanitizeSQLLike(c.Query("search")); search != "" {
+		query = query.Where("name ILIKE ?", "%"+search+"%")
+	}
+	query.Find(&series)
+	return c.JSON(series)
+}
+
+// GetSeries returns a series with its visible books in reading order
+func GetSeries(c *fiber.Ctx) error {
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	var series models.Series
+	if err := database.DB.Where("slug = ?", c.Params("slug")).First(&series).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Series not found",
+		})
+	}
+
+	query := database.DB.Where("series_id = ? AND is_deleted = ?", series.ID, false)
+	query = applyCommunityFilterForBook(query, roleID)
+	query.Preload("AuthorData").
+		Preload("Genres").
+		Order("series_position ASC NULLS LAST, publication_year ASC NULLS LAST, id ASC").
+		Find(&series.Books)
+
+	return c.JSON(series)
+}
+
+// GetBookEditions returns the work a book belongs to and all visible editions of it
+func GetBookEditions(c *fiber.Ctx) error {
+	id, _ := strconv.Atoi(c.Params("id"))
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	book, err := findVisibleBook(uint(id), roleID)
+	if err != nil || book.WorkID == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Book not found",
+		})
+	}
+
+	var work models.Work
+	database.DB.First(&work, *book.WorkID)
+
+	editions := []models.Book{}
+	query := database.DB.Where("work_id = ? AND is_deleted = ?", work.ID, false)
+	query = applyCommunityFilterForBook(query, roleID)
+	query.Preload("Series").
+		Order("publication_year ASC NULLS LAST, id ASC").
+		Find(&editions)
+
+	return c.JSON(fiber.Map{
+		"work":     work,
+		"editions": editions,
+	})
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"backend/models"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestValidateBookMetadataLanguage(t *testing.T) {
+	tests := []struct {
+		language string
+		want     string
+		wantErr  bool
+	}{
+		{"TR", "tr", false},
+		{"en-US", "en-US", false},
+		{"pt-br", "pt-BR", false},
+		{"", "", false},
+		{"turkish", "", true},
+		{"en_US", "", true},
+	}
+	for _, tt := range tests {
+		book := models.Book{Language: tt.language}
+		err := validateBookMetadata(&book)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("validateBookMetadata(language %q) succeeded, want an error", tt.language)
+			}
+			continue
+		}
+		if err != nil || book.Language != tt.want {
+			t.Errorf("validateBookMetadata(language %q) = %q, %v, want %q", tt.language, book.Language, err, tt.want)
+		}
+	}
+}
+
+func TestValidateBookMetadataTruncatesByRunes(t *testing.T) {
+	// 254 ASCII letters followed by "ş" puts a two-byte letter across the byte limit
+	publisher := strings.Repeat("a", maxBookMetadataLength-1) + "şğ"
+	book := models.Book{Publisher: publisher, Translator: strings.Repeat("ş", 300)}
+	if err := validateBookMetadata(&book); err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Repeat("a", maxBookMetadataLength-1) + "ş"; book.Publisher != want {
+		t.Errorf("Publisher = %q, want %q", book.Publisher, want)
+	}
+	if !utf8.ValidString(book.Translator) || utf8.RuneCountInString(book.Translator) != maxBookMetadataLength {
+		t.Errorf("Translator = %q, want %d whole letters", book.Translator, maxBookMetadataLength)
+	}
+}
@@ -0,0 +1,313 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxReviewTitleLength = 150
+	maxReviewBodyLength  = 10000
+)
+
+// bookRatingColumns are the aggregate columns maintained by recalculateBookRatings
+var bookRatingColumns = []string{
+	"rating_count", "rating_average",
+	"rating1_count", "rating2_count", "rating3_count", "rating4_count", "rating5_count",
+}
+
+// clearBookRatings resets aggregates a client may have sent along with a book
+func clearBookRatings(book *models.Book) {
+	book.RatingCount = 0
+	book.RatingAverage = 0
+	book.Rating1Count = 0
+	book.Rating2Count = 0
+	book.Rating3Count = 0
+	book.Rating4Count = 0
+	book.Rating5Count = 0
+}
+
+// validRating reports whether rating is between 1 and 5 in half-star steps
+func validRating(rating float64) bool {
+	return rating >= 1 && rating <= 5 && math.Mod(rating*2, 1) == 0
+}
+
+// lockBook takes a row lock on the book so concurrent review changes recalculate in turn
+func lockBook(tx *gorm.DB, bookID uint) error {
+	var book models.Book
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&book, bookID).Error
+}
+
+// ratingCount is how many reviews of a book gave one rating
+type ratingCount struct {
+	Rating float64
+	Count  int
+}
+
+// bookRatingStats computes the aggregate columns of a book from its rating counts. Half
+// stars count towards the whole star below them; the average is rounded to two decimals.
+func bookRatingStats(counts []ratingCount) map[string]interface{} {
+	stars := [5]int{}
+	total, sum := 0, 0.0
+	for _, row := range counts {
+		star := min(max(int(row.Rating), 1), 5)
+		stars[star-1] += row.Count
+		total += row.Count
+		sum += row.Rating * float64(row.Count)
+	}
+	average := 0.0
+	if total > 0 {
+		average = math.Round(sum/float64(total)*100) / 100
+	}
+	return map[string]interface{}{
+		"rating_count":   total,
+		"rating_average": average,
+		"rating1_count":  stars[0],
+		"rating2_count":  stars[1],
+		"rating3_count":  stars[2],
+		"rating4_count":  stars[3],
+		"rating5_count":  stars[4],
+	}
+}
+
+// recalculateBookRatings rewrites the rating aggregates of a book from its reviews.
+// It must run in the transaction that changed the reviews, after lockBook.
+func recalculateBookRatings(tx *gorm.DB, bookID uint) error {
+	var counts []ratingCount
+	if err := tx.Model(&models.BookReview{}).
+		Select("rating, COUNT(*) AS count").
+		Where("book_id = ?", bookID).
+		Group("rating").
+		Scan(&counts).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Book{}).Where("id = ?", bookID).UpdateColumns(bookRatingStats(counts)).Error
+}
+
+// visibleReviewsQuery returns book_reviews the user may read. Admins see everything; others see
+// reviews by friends (including their own) and by users with a public profile. Held and hidden
+// reviews are only shown to their author.
+func visibleReviewsQuery(userID uint, roleID uint) *gorm.DB {
+	query := database.DB.Model(&models.BookReview{})
+	if roleID != 1 {
+		query = query.
+			Joins("JOIN admins ON admins.id = book_reviews.admin_id").
+			Where("(book_reviews.admin_id IN ? OR admins.is_private = ?)", GetFriendIDs(userID), false).
+			Where("(book_reviews.status = ? OR book_reviews.admin_id = ?)", models.CommentStatusVisible, userID)
+	}
+	return query
+}
+
+// reviewSortOrder maps ?sort= to an ORDER BY for review listings
+func reviewSortOrder(sort string) string {
+	switch sort {
+	case "rating_high":
+		return "book_reviews.rating DESC, book_reviews.created_at DESC"
+	case "rating_low":
+		return "book_reviews.rating ASC, book_reviews.created_at DESC"
+	case "oldest":
+		return "book_reviews.created_at ASC"
+	}
+	return "book_reviews.created_at DESC"
+}
+
+// SetBookReview creates or replaces the session user's rating and review of a book
+func SetBookReview(c *fiber.Ctx) error {
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data struct {
+		Rating           float64 `json:"rating"`
+		Title            string  `json:"title"`
+		Body             string  `json:"body"`
+		ContainsSpoilers bool    `json:"contains_spoilers"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	if !validRating(data.Rating) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Puan 1 ile 5 arasında, yarım yıldız adımlarıyla olmalı",
+		})
+	}
+
+	sanitizer := security.NewSanitizer()
+	title := sanitizer.SanitizePlainText(data.Title)
+	body := sanitizer.SanitizePlainText(data.Body)
+	if len([]rune(title)) > maxReviewTitleLength || len([]rune(body)) > maxReviewBodyLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "İnceleme çok uzun",
+		})
+	}
+
+	status := models.CommentStatusVisible
+	screening := security.NewContentFilter().Check(title + "\n" + body)
+	switch screening.Action {
+	case security.FilterReject:
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"message": "İnceleme uygunsuz ifadeler içeriyor",
+		})
+	case security.FilterHold:
+		status = models.CommentStatusPending
+		fmt.Printf("[SetBookReview] userID=%d review held for review, matches=%v\n", userID, screening.Matches)
+	}
+
+	book, err := findVisibleBook(uint(bookID), roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kitap bulunamadı",
+		})
+	}
+
+	var review models.BookReview
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, book.ID); err != nil {
+			return err
+		}
+
+		now := helpers.AppNow()
+		if err := tx.Where("admin_id = ? AND book_id = ?", userID, book.ID).First(&review).Error; err == nil {
+			review.EditedAt = &now
+		} else {
+			review = models.BookReview{AdminID: userID, BookID: book.ID}
+		}
+		review.Rating = data.Rating
+		review.Title = title
+		review.Body = body
+		review.ContainsSpoilers = data.ContainsSpoilers
+		// A review hidden by a moderator stays hidden until approved again
+		if review.Status != models.CommentStatusHidden {
+			review.Status = status
+		}
+		if err := tx.Save(&review).Error; err != nil {
+			return err
+		}
+		return recalculateBookRatings(tx, book.ID)
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "İnceleme kaydedilemedi",
+		})
+	}
+
+	database.DB.Select("id", "rating_count", "rating_average",
+		"rating1_count", "rating2_count", "rating3_count", "rating4_count", "rating5_count").
+		First(&book, book.ID)
+
+	return c.JSON(fiber.Map{
+		"review": review,
+		"book":   book,
+	})
+}
+
+// DeleteBookReview removes the session user's rating and review of a book
+func DeleteBookReview(c *fiber.Ctx) error {
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+
+	deleted := int64(0)
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, uint(bookID)); err != nil {
+			return err
+		}
+		result := tx.Where("admin_id = ? AND book_id = ?", userID, bookID).Delete(&models.BookReview{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return recalculateBookRatings(tx, uint(bookID))
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "İnceleme silinemedi",
+		})
+	}
+	if deleted == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "İnceleme bulunamadı",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "İnceleme silindi",
+	})
+}
+
+// GetBookReviews lists the reviews of a book the user may read, with the book's rating
+// aggregates and the user's own review. ?sort=recent|oldest|rating_high|rating_low,
+// ?with_text=true skips ratings without a written review.
+func GetBookReviews(c *fiber.Ctx) error {
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	book, err := findVisibleBook(uint(bookID), roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kitap bulunamadı",
+		})
+	}
+
+	params := helpers.GetPaginationParams(c)
+	query := visibleReviewsQuery(userID, roleID).Where("book_reviews.book_id = ?", book.ID)
+	if c.Query("with_text") == "true" {
+		query = query.Where("book_reviews.body <> ''")
+	}
+
+	var total int64
+	query.Count(&total)
+
+	reviews := []models.BookReview{}
+	query.Preload("Admin", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "role_id", "profile_image")
+	}).
+		Order(reviewSortOrder(c.Query("sort"))).
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&reviews)
+
+	var own *models.BookReview
+	var mine models.BookReview
+	if err := database.DB.Where("admin_id = ? AND book_id = ?", userID, book.ID).First(&mine).Error; err == nil {
+		own = &mine
+	}
+
+	return c.JSON(fiber.Map{
+		"book": fiber.Map{
+			"id":             book.ID,
+			"rating_count":   book.RatingCount,
+			"rating_average": book.RatingAverage,
+			"distribution": map[string]int{
+				"1": book.Rating1Count,
+				"2": book.Rating2Count,
+				"3": book.Rating3Count,
+				"4": book.Rating4Count,
+				"5": book.Rating5Count,
+			},
+		},
+		"my_review": own,
+		"result":    helpers.CreatePaginationResponse(reviews, total, params.Offset, params.Limit),
+	})
+}
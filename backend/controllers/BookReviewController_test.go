@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBookRatingStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []ratingCount
+		want   map[string]interface{}
+	}{
+		{
+			"no reviews",
+			nil,
+			map[string]interface{}{
+				"rating_count": 0, "rating_average": 0.0,
+				"rating1_count": 0, "rating2_count": 0, "rating3_count": 0, "rating4_count": 0, "rating5_count": 0,
+			},
+		},
+		{
+			"whole stars",
+			[]ratingCount{{5, 2}, {3, 1}, {1, 1}},
+			map[string]interface{}{
+				"rating_count": 4, "rating_average": 3.5,
+				"rating1_count": 1, "rating2_count": 0, "rating3_count": 1, "rating4_count": 0, "rating5_count": 2,
+			},
+		},
+		{
+			// Half stars count towards the star below them
+			"half stars",
+			[]ratingCount{{4.5, 1}, {1.5, 2}, {4, 1}},
+			map[string]interface{}{
+				"rating_count": 4, "rating_average": 2.88,
+				"rating1_count": 2, "rating2_count": 0, "rating3_count": 0, "rating4_count": 2, "rating5_count": 0,
+			},
+		},
+		{
+			"average rounded to two decimals",
+			[]ratingCount{{5, 1}, {4, 1}, {4, 1}},
+			map[string]interface{}{
+				"rating_count": 3, "rating_average": 4.33,
+				"rating1_count": 0, "rating2_count": 0, "rating3_count": 0, "rating4_count": 2, "rating5_count": 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bookRatingStats(tt.counts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bookRatingStats(%v) = %v, want %v", tt.counts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidRating(t *testing.T) {
+	tests := []struct {
+		rating float64
+		want   bool
+	}{
+		{1, true},
+		{2.5, true},
+		{5, true},
+		{0.5, false},
+		{5.5, false},
+		{3.3, false},
+		{0, false},
+	}
+	for _, tt := range tests {
+		if got := validRating(tt.rating); got != tt.want {
+			t.Errorf("validRating(%v) = %v, want %v", tt.rating, got, tt.want)
+		}
+	}
+}
@@ -4,8 +4,14 @@ import (
 	"backend/database"
 	"backend/helpers"
 	"backend/models"
+	"backend/security"
+	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func AddComment(c *fiber.Ctx) error {
@@ -14,7 +20,15 @@ func AddComment(c *fiber.Ctx) error {
 		return err
 	}
 	bookId, _ := strconv.Atoi(data["book_id"])
-	adminId, _ := strconv.Atoi(data["admin_id"])
+	poemId, _ := strconv.Atoi(data["poem_id"])
+	parentId, _ := strconv.Atoi(data["parent_id"])
+
+	// A comment targets either a book or a poem; replies take the target of their parent
+	if parentId == 0 && (bookId > 0) == (poemId > 0) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "book_id veya poem_id alanlarından biri gönderilmelidir",
+		})
+	}
 
 	// Page is optional, can be nil
 	var page *int
@@ -24,19 +38,72 @@ func AddComment(c *fiber.Ctx) error {
 		}
 	}
 
+	// The author always comes from the session; admin_id in the body is ignored
 	userID := GetUserId(c)
-	if userID != uint(adminId) {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Access denied",
+	if userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
 		})
 	}
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+	// Comments are shown as plain text, so any markup is stripped
+	sanitizer := security.NewSanitizer()
 	comment := models.Comment{
-		AdminID:   uint(adminId),
-		BookID:    uint(bookId),
-		Title:     data["title"],
-		Content:   data["content"],
+		AdminID:   userID,
+		Title:     sanitizer.SanitizePlainText(data["title"]),
+		Content:   sanitizer.SanitizePlainText(data["content"]),
 		Page:      page,
 		IsDeleted: false,
+		Status:    models.CommentStatusVisible,
+	}
+
+	// Screen the text: rejected words refuse the comment, held words queue it for moderation
+	screening := security.NewContentFilter().Check(comment.Title + "\n" + comment.Content)
+	switch screening.Action {
+	case security.FilterReject:
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"message": "Yorum uygunsuz ifadeler içeriyor",
+		})
+	case security.FilterHold:
+		comment.Status = models.CommentStatusPending
+		fmt.Printf("[AddComment] userID=%d comment held for review, matches=%v\n", userID, screening.Matches)
+	}
+
+	var parent models.Comment
+	if parentId > 0 {
+		if err := visibleCommentsQuery(userID, roleID).First(&parent, parentId).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Yanıtlanan yorum bulunamadı",
+			})
+		}
+		if parent.Depth >= commentMaxDepth() {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Bu yoruma daha fazla yanıt verilemez",
+			})
+		}
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = &rootID
+		comment.Depth = parent.Depth + 1
+		comment.BookID = parent.BookID
+		comment.PoemID = parent.PoemID
+	} else if bookId > 0 {
+		id := uint(bookId)
+		comment.BookID = &id
+	} else {
+		if _, err := findVisiblePoem(uint(poemId), roleID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Şiir bulunamadı",
+			})
+		}
+		id := uint(poemId)
+		comment.PoemID = &id
 	}
 	if err := database.DB.Create(&comment).Error; err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -45,8 +112,13 @@ func AddComment(c *fiber.Ctx) error {
 			"error":   err.Error(),
 		})
 	}
-	comments := getCommentsOfBookForUser(int64(comment.BookID), userID)
-	return c.JSON(comments)
+	if parentId > 0 && comment.Status == models.CommentStatusVisible {
+		var replier models.Admin
+		database.DB.Select("id", "username").First(&replier, userID)
+		notifyCommentReply(parent, comment, replier)
+	}
+	notifyCommentMentions(comment, syncCommentMentions(comment))
+	return c.JSON(commentsOfTargetForUser(comment, userID))
 }
 func GetComments(c *fiber.Ctx) error {
 	bookId, _ := strconv.Atoi(c.Params("book_id"))
@@ -83,6 +155,28 @@ func GetComments(c *fiber.Ctx) error {
 
 	return c.JSON(comments)
 }
+
+// GetPoemComments returns a page of comments on a poem, filtered by friendship like book comments
+func GetPoemComments(c *fiber.Ctx) error {
+	poemId, _ := strconv.Atoi(c.Params("poem_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	poem, err := findVisiblePoem(uint(poemId), roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Şiir bulunamadı",
+		})
+	}
+
+	params := helpers.GetPaginationParams(c)
+	return c.JSON(getCommentsOfPoemForUser(poem.ID, userID, roleID, params))
+}
 func DeleteComment(c *fiber.Ctx) error {
 	commentId, _ := strconv.Atoi(c.Params("comment_id"))
 	var comment models.Comment
@@ -93,41 +187,213 @@ func DeleteComment(c *fiber.Ctx) error {
 			"message": "Access denied",
 		})
 	}
-	bookId := comment.BookID
-	database.DB.Delete(&comment)
-	comments := getCommentsOfBookForUser(int64(bookId), userID)
-	return c.JSON(comments)
+	// A comment with replies stays as an empty tombstone so other users' replies keep their place
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var replies int64
+		tx.Model(&models.Comment{}).Where("parent_id = ?", comment.ID).Count(&replies)
+		if replies == 0 {
+			return removeComment(tx, comment)
+		}
+		for _, model := range []interface{}{&models.CommentReaction{}, &models.CommentMention{}, &models.CommentRevision{}} {
+			if err := tx.Where("comment_id = ?", comment.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&comment).Updates(map[string]interface{}{
+			"is_deleted": true,
+			"title":      "",
+			"content":    "",
+		}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Yorum silinemedi",
+		})
+	}
+	return c.JSON(commentsOfTargetForUser(comment, userID))
 }
+
+// removeComment deletes a comment without replies together with its reactions, mentions,
+// revisions and reports. A tombstone parent left without replies is removed as well.
+func removeComment(tx *gorm.DB, comment models.Comment) error {
+	for _, model := range []interface{}{&models.CommentReaction{}, &models.CommentMention{}, &models.CommentRevision{}} {
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("target_type = ? AND target_id = ?", models.ReportTargetComment, comment.ID).Delete(&models.Report{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&comment).Error; err != nil {
+		return err
+	}
+
+	if comment.ParentID == nil {
+		return nil
+	}
+	var parent models.Comment
+	if err := tx.Where("id = ? AND is_deleted = ?", *comment.ParentID, true).First(&parent).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	var replies int64
+	tx.Model(&models.Comment{}).Where("parent_id = ?", parent.ID).Count(&replies)
+	if replies > 0 {
+		return nil
+	}
+	return removeComment(tx, parent)
+}
+
+// UpdateComment edits a comment and keeps the previous text as a revision.
+// Authors can edit within COMMENT_EDIT_WINDOW of posting; moderators can edit any time.
 func UpdateComment(c *fiber.Ctx) error {
 	commentId, _ := strconv.Atoi(c.Params("comment_id"))
 	userID := GetUserId(c)
 	roleID, err := helpers.GetUserRole(c)
-	if err != nil {
+	if err != nil || userID == 0 {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"message": "Unauthorized",
 		})
 	}
 
 	var comment models.Comment
-	database.DB.First(&comment, commentId)
+	if err := database.DB.Where("is_deleted = ?", false).First(&comment, commentId).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yorum bulunamadı",
+		})
+	}
 
-	// Check ownership - only the comment owner or admin can update
-	if roleID != 1 && userID != comment.AdminID {
+	// Check ownership - only the comment owner or a moderator can update
+	moderator := isModerator(roleID)
+	if !moderator && userID != comment.AdminID {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 			"message": "Access denied",
 		})
 	}
+	if !moderator {
+		window := helpers.GetEnvDuration("COMMENT_EDIT_WINDOW", 15*time.Minute)
+		if !commentEditWindowOpen(comment.CreatedAt, window, time.Now()) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Yorumun düzenleme süresi doldu",
+			})
+		}
+	}
 
 	var data map[string]string
 	if err := c.BodyParser(&data); err != nil {
 		return err
 	}
 
-	comment.Title = data["title"]
-	comment.Content = data["content"]
-	database.DB.Save(&comment)
-	comments := getCommentsOfBookForUser(int64(comment.BookID), userID)
-	return c.JSON(comments)
+	sanitizer := security.NewSanitizer()
+	title, hasTitle := data["title"]
+	if hasTitle {
+		title = sanitizer.SanitizePlainText(title)
+	} else {
+		title = comment.Title
+	}
+	content := sanitizer.SanitizePlainText(data["content"])
+	if strings.TrimSpace(content) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Yorum boş olamaz",
+		})
+	}
+	if title == comment.Title && content == comment.Content {
+		return c.JSON(commentsOfTargetForUser(comment, userID))
+	}
+
+	// Edits are screened like new comments; moderators' own edits are trusted
+	status := comment.Status
+	if !moderator {
+		switch security.NewContentFilter().Check(title + "\n" + content).Action {
+		case security.FilterReject:
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": "Yorum uygunsuz ifadeler içeriyor",
+			})
+		case security.FilterHold:
+			if status == models.CommentStatusVisible {
+				status = models.CommentStatusPending
+			}
+		}
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		revision := models.CommentRevision{
+			CommentID: comment.ID,
+			EditorID:  userID,
+			Title:     comment.Title,
+			Content:   comment.Content,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		comment.Title = title
+		comment.Content = content
+		comment.EditedAt = &now
+		comment.EditCount++
+		comment.Status = status
+		return tx.Model(&comment).Select("title", "content", "edited_at", "edit_count", "status").Updates(&comment).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Yorum güncellenemedi",
+		})
+	}
+
+	// Only users newly mentioned by this edit are notified
+	notifyCommentMentions(comment, syncCommentMentions(comment))
+	return c.JSON(commentsOfTargetForUser(comment, userID))
+}
+
+// GetCommentRevisions returns every earlier version of a comment, newest first (moderators only)
+func GetCommentRevisions(c *fiber.Ctx) error {
+	commentId, _ := strconv.Atoi(c.Params("comment_id"))
+
+	var comment models.Comment
+	if err := database.DB.First(&comment, commentId).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yorum bulunamadı",
+		})
+	}
+
+	revisions := []models.CommentRevision{}
+	database.DB.Where("comment_id = ?", comment.ID).
+		Preload("Editor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "role_id")
+		}).
+		Order("id DESC").
+		Find(&revisions)
+
+	return c.JSON(fiber.Map{
+		"comment":   comment,
+		"revisions": revisions,
+	})
+}
+
+// commentEditWindowOpen reports whether a comment posted at createdAt can still be edited by its author
+func commentEditWindowOpen(createdAt *time.Time, window time.Duration, now time.Time) bool {
+	return createdAt != nil && now.Sub(*createdAt) <= window
+}
+
+// isModerator reports whether the role may moderate comments
+func isModerator(roleID uint) bool {
+	return roleID == 1
+}
+
+// commentsOfTargetForUser returns the refreshed comment list of the comment's book or poem
+func commentsOfTargetForUser(comment models.Comment, userID uint) interface{} {
+	if comment.PoemID != nil {
+		var admin models.Admin
+		database.DB.First(&admin, userID)
+		return getCommentsOfPoemForUser(*comment.PoemID, userID, admin.RoleID, helpers.PaginationParams{Offset: 0, Limit: 20})
+	}
+	if comment.BookID != nil {
+		return getCommentsOfBookForUser(int64(*comment.BookID), userID)
+	}
+	return []models.Comment{}
 }
 
 // getCommentsOfBook returns all comments for a book (admin only)
@@ -137,27 +403,93 @@ func getCommentsOfBook(id int64) []models.Comment {
 	return comments
 }
 
-// getCommentsOfBookForUser returns comments filtered by friendship
+// getCommentsOfBookForUser returns the book's comment threads filtered by friendship
 func getCommentsOfBookForUser(bookID int64, userID uint) []models.Comment {
-	var comments []models.Comment
+	var roots []models.Comment
 
 	// Get user role
 	var admin models.Admin
 	database.DB.First(&admin, userID)
 
-	// Admin can see all comments
-	if admin.RoleID == 1 {
-		database.DB.Where("book_id = ?", bookID).Preload("Admin").Preload("Book").Find(&comments)
-		return comments
+	threadCommentsQuery(userID, admin.RoleID).
+		Where("book_id = ? AND parent_id IS NULL", bookID).
+		Preload("Book").
+		Order("id ASC").
+		Find(&roots)
+
+	return loadCommentThreads(roots, userID, admin.RoleID)
+}
+
+// getCommentsOfPoemForUser returns a page of a poem's comment threads, oldest first, filtered by friendship.
+// Pagination counts top-level comments; each carries its replies.
+func getCommentsOfPoemForUser(poemID uint, userID uint, roleID uint, params helpers.PaginationParams) helpers.PaginationResponse {
+	query := threadCommentsQuery(userID, roleID).Where("poem_id = ? AND parent_id IS NULL", poemID)
+
+	var total int64
+	query.Session(&gorm.Session{}).Model(&models.Comment{}).Count(&total)
+
+	var roots []models.Comment
+	query.Order("id ASC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&roots)
+
+	return helpers.CreatePaginationResponse(loadCommentThreads(roots, userID, roleID), total, params.Offset, params.Limit)
+}
+
+// visibleCommentsQuery scopes comments to the ones the user may read: admins see all,
+// everyone else sees their own and their friends' comments. Held and hidden comments
+// are only shown to their author.
+func visibleCommentsQuery(userID uint, roleID uint) *gorm.DB {
+	return commentAccessFilter(database.DB.Where("comments.is_deleted = ?", false), userID, roleID)
+}
+
+// threadCommentsQuery is visibleCommentsQuery plus deleted comments that still have
+// replies, which threads show as tombstones so the replies stay reachable
+func threadCommentsQuery(userID uint, roleID uint) *gorm.DB {
+	query := database.DB.Where(`(comments.is_deleted = ? OR EXISTS (
+		SELECT 1 FROM comments AS replies WHERE replies.parent_id = comments.id))`, false)
+	return commentAccessFilter(query, userID, roleID)
+}
+
+// commentAccessFilter applies the friendship and moderation status rules of comment reads
+func commentAccessFilter(query *gorm.DB, userID uint, roleID uint) *gorm.DB {
+	if roleID != 1 {
+		query = query.Where("comments.admin_id IN ?", GetFriendIDs(userID)).
+			Where("(comments.status = ? OR comments.admin_id = ?)", models.CommentStatusVisible, userID)
 	}
+	return query
+}
 
-	// Regular users can only see comments from their friends (and themselves)
-	friendIDs := GetFriendIDs(userID)
+// visiblePoemCommentCounts counts the comments the user may read on each poem
+func visiblePoemCommentCounts(poemIDs []uint, userID uint, roleID uint) map[uint]int {
+	counts := make(map[uint]int, len(poemIDs))
+	if len(poemIDs) == 0 {
+		return counts
+	}
 
-	database.DB.Where("book_id = ? AND admin_id IN ?", bookID, friendIDs).
-		Preload("Admin").
-		Preload("Book").
-		Find(&comments)
+	var rows []struct {
+		PoemID uint
+		Count  int
+	}
+	visibleCommentsQuery(userID, roleID).
+		Model(&models.Comment{}).
+		Select("poem_id, COUNT(*) AS count").
+		Where("poem_id IN ?", poemIDs).
+		Group("poem_id").
+		Scan(&rows)
 
-	return comments
+	for _, row := range rows {
+		counts[row.PoemID] = row.Count
+	}
+	return counts
+}
+
+// findVisiblePoem loads a non-deleted poem the role is allowed to see
+func findVisiblePoem(poemID uint, roleID uint) (models.Poem, error) {
+	var poem models.Poem
+	query := database.DB.Where("id = ? AND is_deleted = ?", poemID, false)
+	query = applyCommunityFilter(query, roleID)
+	err := query.First(&poem).Error
+	return poem, err
 }
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAddCommentRequiresOneTarget(t *testing.T) {
+	app := fiber.New()
+	app.Post("/comments", AddComment)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"no target", `{"content":"güzel"}`},
+		{"book and poem", `{"book_id":"3","poem_id":"7","content":"güzel"}`},
+		{"zero ids", `{"book_id":"0","poem_id":"0","content":"güzel"}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/comments", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, fiber.StatusBadRequest)
+		}
+	}
+}
+
+func TestCommentEditWindowOpen(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) *time.Time {
+		created := now.Add(-ago)
+		return &created
+	}
+	tests := []struct {
+		name      string
+		createdAt *time.Time
+		want      bool
+	}{
+		{"just posted", at(0), true},
+		{"inside window", at(10 * time.Minute), true},
+		{"at window end", at(15 * time.Minute), true},
+		{"after window", at(15*time.Minute + time.Second), false},
+		{"unknown creation time", nil, false},
+	}
+	for _, tt := range tests {
+		if got := commentEditWindowOpen(tt.createdAt, 15*time.Minute, now); got != tt.want {
+			t.Errorf("%s: commentEditWindowOpen = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
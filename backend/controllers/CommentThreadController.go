@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	ws "backend/websocket"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// commentReactionEmojis are the reactions users can leave on a comment
+var commentReactionEmojis = map[string]bool{
+	"👍":  true,
+	"❤️": true,
+	"😂":  true,
+	"😮":  true,
+	"😢":  true,
+	"🙏":  true,
+}
+
+// commentMaxDepth is the deepest reply level allowed (top-level comments are depth 0)
+func commentMaxDepth() int {
+	return helpers.GetEnvInt("COMMENT_MAX_DEPTH", 3)
+}
+
+// loadCommentThreads attaches visible replies, authors, reactions and mentions to top-level comments.
+// The whole thread set is loaded with a fixed number of queries regardless of its size.
+func loadCommentThreads(roots []models.Comment, userID uint, roleID uint) []models.Comment {
+	if len(roots) == 0 {
+		return []models.Comment{}
+	}
+
+	rootIDs := make([]uint, 0, len(roots))
+	for _, root := range roots {
+		rootIDs = append(rootIDs, root.ID)
+	}
+
+	var replies []models.Comment
+	threadCommentsQuery(userID, roleID).
+		Where("root_id IN ?", rootIDs).
+		Order("id ASC").
+		Find(&replies)
+
+	// Authors of every comment in one query
+	all := append(append([]models.Comment{}, roots...), replies...)
+	adminIDs := make([]uint, 0, len(all))
+	commentIDs := make([]uint, 0, len(all))
+	for _, comment := range all {
+		adminIDs = append(adminIDs, comment.AdminID)
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	var admins []models.Admin
+	database.DB.Select("id", "username", "role_id", "profile_image").Where("id IN ?", adminIDs).Find(&admins)
+	adminsByID := make(map[uint]models.Admin, len(admins))
+	for _, admin := range admins {
+		adminsByID[admin.ID] = admin
+	}
+
+	reactions := loadCommentReactions(commentIDs, userID)
+	mentions := loadCommentMentions(commentIDs)
+
+	// Replies whose parent is hidden or deleted are never reached from a root
+	children := make(map[uint][]models.Comment)
+	for _, reply := range replies {
+		if reply.ParentID != nil {
+			children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+		}
+	}
+
+	var build func(comment models.Comment) models.Comment
+	build = func(comment models.Comment) models.Comment {
+		if comment.IsDeleted {
+			// Tombstone of a deleted comment with replies; moderator deletions keep the text stored
+			comment.Title, comment.Content = "", ""
+		}
+		comment.Admin = adminsByID[comment.AdminID]
+		comment.Reactions = reactions[comment.ID]
+		comment.Mentions = mentions[comment.ID]
+		for _, child := range children[comment.ID] {
+			comment.Replies = append(comment.Replies, build(child))
+		}
+		return comment
+	}
+
+	threads := make([]models.Comment, 0, len(roots))
+	for _, root := range roots {
+		threads = append(threads, build(root))
+	}
+	return threads
+}
+
+// loadCommentReactions returns emoji counts per comment and whether the viewer reacted
+func loadCommentReactions(commentIDs []uint, userID uint) map[uint][]models.CommentReactionCount {
+	reactions := make(map[uint][]models.CommentReactionCount)
+	if len(commentIDs) == 0 {
+		return reactions
+	}
+
+	var rows []struct {
+		CommentID uint
+		models.CommentReactionCount
+	}
+	database.DB.Model(&models.CommentReaction{}).
+		Select("comment_id, emoji, COUNT(*) AS count, BOOL_OR(admin_id = ?) AS reacted", userID).
+		Where("comment_id IN ?", commentIDs).
+		Group("comment_id, emoji").
+		Order("comment_id, count DESC, emoji").
+		Scan(&rows)
+
+	for _, row := range rows {
+		reactions[row.CommentID] = append(reactions[row.CommentID], row.CommentReactionCount)
+	}
+	return reactions
+}
+
+// attachBookCommentThreads loads the visible comment threads of each book
+func attachBookCommentThreads(books []models.Book, userID uint, roleID uint) {
+	if len(books) == 0 {
+		return
+	}
+
+	bookIDs := make([]uint, 0, len(books))
+	for _, book := range books {
+		bookIDs = append(bookIDs, book.ID)
+	}
+
+	var roots []models.Comment
+	threadCommentsQuery(userID, roleID).
+		Where("book_id IN ? AND parent_id IS NULL", bookIDs).
+		Order("id ASC").
+		Find(&roots)
+
+	byBook := make(map[uint][]models.Comment, len(books))
+	for _, thread := range loadCommentThreads(roots, userID, roleID) {
+		byBook[*thread.BookID] = append(byBook[*thread.BookID], thread)
+	}
+	for i := range books {
+		books[i].Comments = byBook[books[i].ID]
+		if books[i].Comments == nil {
+			books[i].Comments = []models.Comment{}
+		}
+	}
+}
+
+// notifyCommentReply tells the parent comment's author about a new reply
+func notifyCommentReply(parent models.Comment, reply models.Comment, replier models.Admin) {
+	if parent.AdminID == reply.AdminID {
+		return
+	}
+
+	fmt.Printf("[WebSocket] Sending comment_reply to user %d for comment %d\n", parent.AdminID, parent.ID)
+	ws.GlobalHub.SendToUser(parent.AdminID, "comment_reply", map[string]interface{}{
+		"comment_id":    reply.ID,
+		"parent_id":     parent.ID,
+		"book_id":       reply.BookID,
+		"poem_id":       reply.PoemID,
+		"from_user_id":  replier.ID,
+		"from_username": replier.Username,
+		"content":       reply.Content,
+	})
+}
+
+// GetCommentThread returns the whole thread a comment belongs to
+func GetCommentThread(c *fiber.Ctx) error {
+	commentId, _ := strconv.Atoi(c.Params("comment_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var comment models.Comment
+	if err := visibleCommentsQuery(userID, roleID).First(&comment, commentId).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yorum bulunamadı",
+		})
+	}
+
+	root := comment
+	if comment.RootID != nil {
+		if err := threadCommentsQuery(userID, roleID).First(&root, *comment.RootID).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Yorum bulunamadı",
+			})
+		}
+	}
+
+	threads := loadCommentThreads([]models.Comment{root}, userID, roleID)
+	return c.JSON(threads[0])
+}
+
+// ToggleCommentReaction adds the emoji reaction of the current user, or removes it if already present
+func ToggleCommentReaction(c *fiber.Ctx) error {
+	commentId, _ := strconv.Atoi(c.Params("comment_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data map[string]string
+	if err := c.BodyParser(&data); err != nil {
+		return err
+	}
+	emoji := data["emoji"]
+	if !commentReactionEmojis[emoji] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz tepki",
+		})
+	}
+
+	var comment models.Comment
+	if err := visibleCommentsQuery(userID, roleID).First(&comment, commentId).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yorum bulunamadı",
+		})
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("comment_id = ? AND admin_id = ? AND emoji = ?", comment.ID, userID, emoji).
+			Delete(&models.CommentReaction{})
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+		// A concurrent toggle may have added the same reaction; the unique index keeps one
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.CommentReaction{CommentID: comment.ID, AdminID: userID, Emoji: emoji}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Tepki kaydedilemedi",
+		})
+	}
+
+	reactions := loadCommentReactions([]uint{comment.ID}, userID)[comment.ID]
+	if reactions == nil {
+		reactions = []models.CommentReactionCount{}
+	}
+	return c.JSON(fiber.Map{
+		"comment_id": comment.ID,
+		"reactions":  reactions,
+	})
+}
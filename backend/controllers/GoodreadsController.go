@@ -0,0 +1,682 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// goodreadsColumns is the header of a Goodreads library export, in order
+var goodreadsColumns = []string{
+	"Book Id", "Title", "Author", "Author l-f", "Additional Authors", "ISBN", "ISBN13",
+	"My Rating", "Average Rating", "Publisher", "Binding", "Number of Pages", "Year Published",
+	"Original Publication Year", "Date Read", "Date Added", "Bookshelves",
+	"Bookshelves with positions", "Exclusive Shelf", "My Review", "Spoiler", "Private Notes",
+	"Read Count", "Owned Copies",
+}
+
+// goodreadsDateLayout is how Goodreads writes dates (2019/05/12)
+const goodreadsDateLayout = "2006/01/02"
+
+// goodreadsShelves maps Goodreads exclusive shelves to built-in shelves
+var goodreadsShelves = map[string]string{
+	"read":              models.ShelfFinished,
+	"currently-reading": models.ShelfReading,
+	"to-read":           models.ShelfWantToRead,
+	"abandoned":         models.ShelfAbandoned,
+	"did-not-finish":    models.ShelfAbandoned,
+	"dnf":               models.ShelfAbandoned,
+}
+
+// goodreadsExclusiveShelves maps built-in shelves back to Goodreads exclusive shelves
+var goodreadsExclusiveShelves = map[string]string{
+	models.ShelfFinished:   "read",
+	models.ShelfReading:    "currently-reading",
+	models.ShelfWantToRead: "to-read",
+	models.ShelfAbandoned:  "abandoned",
+}
+
+// goodreadsLineBreaks turns the line breaks Goodreads keeps in reviews into newlines
+var goodreadsLineBreaks = strings.NewReplacer("<br/>", "\n", "<br />", "\n", "<br>", "\n")
+
+// goodreadsSeriesSuffix matches the series Goodreads appends to titles: "Dune (Dune, #1)"
+var goodreadsSeriesSuffix = regexp.MustCompile(`\s*\([^()]*#[^()]*\)\s*$`)
+
+// Goodreads row statuses
+const (
+	GoodreadsRowImported  = "imported"
+	GoodreadsRowTracked   = "already_tracked" // the book was already on a shelf; shelves and rating may still be added
+	GoodreadsRowUnmatched = "unmatched"
+	GoodreadsRowInvalid   = "invalid"
+)
+
+// GoodreadsRowResult is the outcome of one row of a Goodreads export
+type GoodreadsRowResult struct {
+	Row       int      `json:"row"`
+	Title     string   `json:"title"`
+	Author    string   `json:"author"`
+	ISBN      string   `json:"isbn,omitempty"`
+	Status    string   `json:"status"`
+	BookID    uint     `json:"book_id,omitempty"`
+	MatchedBy string   `json:"matched_by,omitempty"` // isbn or title_author
+	Shelf     string   `json:"shelf,omitempty"`
+	Shelves   []string `json:"shelves,omitempty"`
+	Rating    float64  `json:"rating,omitempty"`
+	Notes     []string `json:"notes,omitempty"`
+}
+
+// GoodreadsImportReport summarizes a Goodreads import
+type GoodreadsImportReport struct {
+	DryRun    bool                 `json:"dry_run"`
+	Total     int                  `json:"total"`
+	Imported  int                  `json:"imported"`
+	Tracked   int                  `json:"already_tracked"`
+	Unmatched int                  `json:"unmatched"`
+	Invalid   int                  `json:"invalid"`
+	Rows      []GoodreadsRowResult `json:"rows"`
+}
+
+// goodreadsRow is a parsed Goodreads export row
+type goodreadsRow struct {
+	result    GoodreadsRowResult
+	isbn10    string
+	isbn13    string
+	shelf     string
+	shelves   []string
+	rating    int
+	review    string
+	spoiler   bool
+	dateRead  *time.Time
+	dateAdded *time.Time
+}
+
+// goodreadsCatalog matches Goodreads rows to books the user can see
+type goodreadsCatalog struct {
+	byISBN        map[string]models.Book
+	byTitleAuthor map[string]models.Book
+}
+
+// goodreadsTitleKey normalizes a title, dropping the series suffix Goodreads adds
+func goodreadsTitleKey(title string) string {
+	return helpers.NormalizeName(goodreadsSeriesSuffix.ReplaceAllString(title, ""))
+}
+
+func loadGoodreadsCatalog(roleID uint) goodreadsCatalog {
+	var books []struct {
+		ID         uint
+		Name       string
+		Author     string
+		ISBN10     string `gorm:"column:isbn10"`
+		ISBN13     string `gorm:"column:isbn13"`
+		Page       int
+		AuthorName string
+	}
+	query := database.DB.Table("books").
+		Select("books.id, books.name, books.author, books.isbn10, books.isbn13, books.page, COALESCE(authors.name, '') AS author_name").
+		Joins("LEFT JOIN authors ON authors.id = books.author_id").
+		Where("books.is_deleted = ?", false)
+	if roleID != 1 && roleID != 2 {
+		query = query.Where("books.community = ?", 2)
+	}
+	query.Scan(&books)
+
+	catalog := goodreadsCatalog{byISBN: map[string]models.Book{}, byTitleAuthor: map[string]models.Book{}}
+	for _, entry := range books {
+		book := models.Book{ID: entry.ID, Name: entry.Name, Author: entry.Author, ISBN10: entry.ISBN10, ISBN13: entry.ISBN13, Page: entry.Page}
+		if book.ISBN13 != "" {
+			catalog.byISBN[book.ISBN13] = book
+		}
+		if book.ISBN10 != "" {
+			catalog.byISBN[book.ISBN10] = book
+		}
+		title := goodreadsTitleKey(book.Name)
+		for _, author := range []string{entry.AuthorName, book.Author} {
+			if author != "" {
+				catalog.byTitleAuthor[title+"|"+helpers.NormalizeName(author)] = book
+			}
+		}
+	}
+	return catalog
+}
+
+// match finds the book of a row by ISBN-13, ISBN-10, then title and author. Titles are
+// also tried without a subtitle ("Title: Subtitle").
+func (catalog goodreadsCatalog) match(row *goodreadsRow) (models.Book, bool) {
+	for _, isbn := range []string{row.isbn13, row.isbn10} {
+		if book, ok := catalog.byISBN[isbn]; ok && isbn != "" {
+			row.result.MatchedBy = "isbn"
+			return book, true
+		}
+	}
+	author := helpers.NormalizeName(row.result.Author)
+	titles := []string{row.result.Title}
+	if before, _, found := strings.Cut(row.result.Title, ":"); found {
+		titles = append(titles, before)
+	}
+	for _, title := range titles {
+		if book, ok := catalog.byTitleAuthor[goodreadsTitleKey(title)+"|"+author]; ok {
+			row.result.MatchedBy = "title_author"
+			return book, true
+		}
+	}
+	return models.Book{}, false
+}
+
+// parseGoodreadsDate reads a Goodreads date; an empty or unreadable date is nil
+func parseGoodreadsDate(value string) *time.Time {
+	for _, layout := range []string{goodreadsDateLayout, helpers.DateLayout} {
+		if date, err := time.ParseInLocation(layout, strings.TrimSpace(value), helpers.AppLocation()); err == nil {
+			return &date
+		}
+	}
+	return nil
+}
+
+// goodreadsISBN strips the ="..." wrapper Goodreads puts around ISBNs
+func goodreadsISBN(value string) string {
+	return security.NormalizeISBN(strings.Trim(strings.TrimSpace(value), `="`))
+}
+
+// csvFormulaPrefixes start a cell that spreadsheet apps would evaluate as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVCell prefixes a user-written value that would run as a spreadsheet formula with '
+func escapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCSVCell removes the ' added by escapeCSVCell, so exports import back unchanged
+func unescapeCSVCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// parseGoodreadsCSV reads a Goodreads library export
+func parseGoodreadsCSV(data []byte) ([]goodreadsRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("CSV başlığı okunamadı: %v", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, required := range []string{"Title", "Author", "Exclusive Shelf"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("Goodreads dışa aktarma dosyası değil: %q sütunu eksik", required)
+		}
+	}
+
+	var rows []goodreadsRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row := goodreadsRow{result: GoodreadsRowResult{Row: line}}
+		if err != nil {
+			row.result.Status = GoodreadsRowInvalid
+			row.result.Notes = []string{err.Error()}
+			rows = append(rows, row)
+			continue
+		}
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(unescapeCSVCell(record[i]))
+			}
+			return ""
+		}
+
+		row.result.Title = get("Title")
+		row.result.Author = get("Author")
+		row.isbn10 = goodreadsISBN(get("ISBN"))
+		row.isbn13 = goodreadsISBN(get("ISBN13"))
+		if row.isbn13 == "" && security.IsValidISBN10(row.isbn10) {
+			row.isbn13 = security.ISBN10To13(row.isbn10)
+		}
+		row.result.ISBN = row.isbn13
+		row.shelf = strings.ToLower(get("Exclusive Shelf"))
+		row.rating, _ = strconv.Atoi(get("My Rating"))
+		row.review = get("My Review")
+		row.spoiler = get("Spoiler") == "true"
+		row.dateRead = parseGoodreadsDate(get("Date Read"))
+		row.dateAdded = parseGoodreadsDate(get("Date Added"))
+		for _, shelf := range strings.Split(get("Bookshelves"), ",") {
+			shelf = strings.ToLower(strings.TrimSpace(shelf))
+			if _, builtIn := goodreadsShelves[shelf]; shelf != "" && !builtIn && shelf != row.shelf {
+				row.shelves = append(row.shelves, shelf)
+			}
+		}
+
+		if row.result.Title == "" {
+			row.result.Status = GoodreadsRowInvalid
+			row.result.Notes = append(row.result.Notes, "Başlık boş")
+		}
+		if row.rating < 0 || row.rating > 5 {
+			row.result.Notes = append(row.result.Notes, "Geçersiz puan yok sayıldı")
+			row.rating = 0
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// goodreadsShelfFor maps a row's exclusive shelf to a built-in shelf. Custom exclusive shelves
+// become want to read and are kept as a custom shelf.
+func goodreadsShelfFor(row *goodreadsRow) string {
+	if status, ok := goodreadsShelves[row.shelf]; ok {
+		return status
+	}
+	if row.shelf != "" {
+		row.shelves = append(row.shelves, row.shelf)
+	}
+	return models.ShelfWantToRead
+}
+
+// importGoodreadsRow applies one matched row for the user inside the import transaction
+func importGoodreadsRow(tx *gorm.DB, userID uint, book models.Book, row *goodreadsRow, shelves map[string]*models.Shelf, now time.Time) error {
+	when := now
+	if row.dateAdded != nil {
+		when = *row.dateAdded
+	}
+
+	progress, err := loadOrStartProgress(tx, userID, book)
+	if err != nil {
+		return err
+	}
+	if progress.ID != 0 {
+		row.result.Status = GoodreadsRowTracked
+	} else {
+		row.result.Status = GoodreadsRowImported
+		changedAt := when
+		if row.result.Shelf == models.ShelfFinished && row.dateRead != nil {
+			changedAt = *row.dateRead
+		}
+		applyShelfStatus(&progress, row.result.Shelf, changedAt)
+		// Goodreads exports have no start date; a finished book's start stays unknown
+		if row.result.Shelf == models.ShelfFinished {
+			progress.StartedAt = nil
+		}
+		if err := saveProgress(tx, &progress, "", changedAt); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range row.shelves {
+		shelf, ok := shelves[name]
+		if !ok {
+			row.result.Notes = append(row.result.Notes, fmt.Sprintf("%q rafı oluşturulamadı", name))
+			continue
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.ShelfBook{ShelfID: shelf.ID, BookID: book.ID, CreatedAt: when})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		if err := tx.Create(&models.ShelfMove{
+			AdminID:   userID,
+			BookID:    book.ID,
+			Action:    models.ShelfMoveAdd,
+			ShelfID:   &shelf.ID,
+			CreatedAt: when,
+		}).Error; err != nil {
+			return err
+		}
+		row.result.Shelves = append(row.result.Shelves, shelf.Name)
+	}
+
+	if row.rating == 0 {
+		return nil
+	}
+	var existing int64
+	tx.Model(&models.BookReview{}).Where("admin_id = ? AND book_id = ?", userID, book.ID).Count(&existing)
+	if existing > 0 {
+		row.result.Notes = append(row.result.Notes, "Mevcut puan korundu")
+		return nil
+	}
+
+	sanitizer := security.NewSanitizer()
+	body := sanitizer.SanitizePlainText(goodreadsLineBreaks.Replace(row.review))
+	if len([]rune(body)) > maxReviewBodyLength {
+		body = string([]rune(body)[:maxReviewBodyLength])
+	}
+	status := models.CommentStatusVisible
+	switch security.NewContentFilter().Check(body).Action {
+	case security.FilterReject:
+		body = ""
+		row.result.Notes = append(row.result.Notes, "İnceleme metni uygunsuz ifadeler içerdiği için alınmadı")
+	case security.FilterHold:
+		status = models.CommentStatusPending
+	}
+
+	if err := lockBook(tx, book.ID); err != nil {
+		return err
+	}
+	review := models.BookReview{
+		AdminID:          userID,
+		BookID:           book.ID,
+		Rating:           float64(row.rating),
+		Body:             body,
+		ContainsSpoilers: row.spoiler,
+		Status:           status,
+	}
+	if row.dateRead != nil {
+		review.CreatedAt = *row.dateRead
+	}
+	if err := tx.Create(&review).Error; err != nil {
+		return err
+	}
+	row.result.Rating = review.Rating
+	return recalculateBookRatings(tx, book.ID)
+}
+
+// prepareGoodreadsShelves finds or creates the user's custom shelves named in the import,
+// within the per-user shelf limit
+func prepareGoodreadsShelves(tx *gorm.DB, userID uint, rows []goodreadsRow) (map[string]*models.Shelf, error) {
+	var existing []models.Shelf
+	tx.Where("admin_id = ?", userID).Find(&existing)
+	bySlug := map[string]*models.Shelf{}
+	for i := range existing {
+		bySlug[existing[i].Slug] = &existing[i]
+	}
+
+	shelves := map[string]*models.Shelf{}
+	count := len(existing)
+	sanitizer := security.NewSanitizer()
+	for _, row := range rows {
+		if row.result.BookID == 0 {
+			continue
+		}
+		for _, name := range row.shelves {
+			if _, done := shelves[name]; done {
+				continue
+			}
+			slug := helpers.Slugify(name)
+			if shelf, ok := bySlug[slug]; ok {
+				shelves[name] = shelf
+				continue
+			}
+			shelfName := strings.TrimSpace(sanitizer.SanitizePlainText(name))
+			if shelfName == "" || len([]rune(shelfName)) > 64 || count >= maxShelvesPerUser ||
+				isShelfStatus(strings.ReplaceAll(slug, "-", "_")) {
+				continue
+			}
+			shelf := models.Shelf{AdminID: userID, Name: shelfName, Slug: slug}
+			if err := tx.Create(&shelf).Error; err != nil {
+				return nil, err
+			}
+			count++
+			bySlug[slug] = &shelf
+			shelves[name] = &shelf
+		}
+	}
+	return shelves, nil
+}
+
+// ImportGoodreads imports the session user's reading list from an uploaded Goodreads library
+// export ("file"). Books are matched by ISBN or title and author; shelves, read dates and
+// ratings are carried over. Books already on a shelf keep their status and existing ratings
+// are not replaced, so the same export can be imported again. ?dry_run=true only reports.
+func ImportGoodreads(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Goodreads CSV dosyası gerekli",
+		})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Dosya okunamadı",
+		})
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Dosya okunamadı",
+		})
+	}
+
+	rows, err := parseGoodreadsCSV(data)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	if maxRows := helpers.GetEnvInt("GOODREADS_IMPORT_MAX_ROWS", 5000); len(rows) > maxRows {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": fmt.Sprintf("En fazla %d satır içe aktarılabilir", maxRows),
+		})
+	}
+
+	catalog := loadGoodreadsCatalog(roleID)
+	books := make([]models.Book, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		if row.result.Status == GoodreadsRowInvalid {
+			continue
+		}
+		book, ok := catalog.match(row)
+		if !ok {
+			row.result.Status = GoodreadsRowUnmatched
+			continue
+		}
+		books[i] = book
+		row.result.BookID = book.ID
+		row.result.Shelf = goodreadsShelfFor(row)
+		if row.rating > 0 {
+			row.result.Rating = float64(row.rating)
+		}
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+	if dryRun {
+		var trackedIDs []uint
+		database.DB.Model(&models.BookProgress{}).Where("admin_id = ?", userID).Pluck("book_id", &trackedIDs)
+		tracked := make(map[uint]bool, len(trackedIDs))
+		for _, id := range trackedIDs {
+			tracked[id] = true
+		}
+		for i := range rows {
+			if rows[i].result.BookID == 0 {
+				continue
+			}
+			rows[i].result.Status = GoodreadsRowImported
+			if tracked[rows[i].result.BookID] {
+				rows[i].result.Status = GoodreadsRowTracked
+			}
+			rows[i].result.Shelves = rows[i].shelves
+		}
+	} else {
+		now := helpers.AppNow()
+		err = database.DB.Transaction(func(tx *gorm.DB) error {
+			shelves, err := prepareGoodreadsShelves(tx, userID, rows)
+			if err != nil {
+				return err
+			}
+			for i := range rows {
+				if rows[i].result.BookID == 0 {
+					continue
+				}
+				if err := importGoodreadsRow(tx, userID, books[i], &rows[i], shelves, now); err != nil {
+					return fmt.Errorf("row %d: %w", rows[i].result.Row, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("[ImportGoodreads] userID=%d failed: %v\n", userID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "İçe aktarma başarısız oldu, hiçbir şey kaydedilmedi",
+			})
+		}
+		checkReadingMilestones(userID)
+	}
+
+	report := GoodreadsImportReport{DryRun: dryRun, Rows: make([]GoodreadsRowResult, 0, len(rows))}
+	for _, row := range rows {
+		switch row.result.Status {
+		case GoodreadsRowImported:
+			report.Imported++
+		case GoodreadsRowTracked:
+			report.Tracked++
+		case GoodreadsRowUnmatched:
+			report.Unmatched++
+		case GoodreadsRowInvalid:
+			report.Invalid++
+		}
+		report.Total++
+		report.Rows = append(report.Rows, row.result)
+	}
+
+	fmt.Printf("[ImportGoodreads] userID=%d, dry_run=%t, total=%d, imported=%d, tracked=%d, unmatched=%d\n",
+		userID, dryRun, report.Total, report.Imported, report.Tracked, report.Unmatched)
+
+	return c.JSON(report)
+}
+
+// ExportGoodreads downloads the session user's shelved books as a Goodreads library export
+func ExportGoodreads(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	if userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var progress []models.BookProgress
+	database.DB.
+		Joins("JOIN books ON books.id = book_progresses.book_id AND books.is_deleted = ?", false).
+		Preload("Book.AuthorData").
+		Where("book_progresses.admin_id = ?", userID).
+		Order("book_progresses.created_at ASC").
+		Find(&progress)
+
+	var reviews []models.BookReview
+	database.DB.Where("admin_id = ?", userID).Find(&reviews)
+	reviewByBook := make(map[uint]models.BookReview, len(reviews))
+	for _, review := range reviews {
+		reviewByBook[review.BookID] = review
+	}
+
+	var shelfRows []struct {
+		BookID uint
+		Slug   string
+	}
+	database.DB.Table("shelf_books").
+		Select("shelf_books.book_id, shelves.slug").
+		Joins("JOIN shelves ON shelves.id = shelf_books.shelf_id").
+		Where("shelves.admin_id = ?", userID).
+		Order("shelves.slug").
+		Scan(&shelfRows)
+	shelvesByBook := map[uint][]string{}
+	for _, row := range shelfRows {
+		shelvesByBook[row.BookID] = append(shelvesByBook[row.BookID], row.Slug)
+	}
+
+	formatDate := func(date *time.Time) string {
+		if date == nil {
+			return ""
+		}
+		return date.In(helpers.AppLocation()).Format(goodreadsDateLayout)
+	}
+	quoteISBN := func(isbn string) string {
+		return `="` + isbn + `"`
+	}
+
+	var out bytes.Buffer
+	writer := csv.NewWriter(&out)
+	writer.Write(goodreadsColumns)
+	for _, entry := range progress {
+		if entry.Book == nil {
+			continue
+		}
+		book := entry.Book
+		author := book.Author
+		if book.AuthorData != nil {
+			author = book.AuthorData.Name
+		}
+		authorLastFirst := author
+		if parts := strings.Fields(author); len(parts) > 1 {
+			authorLastFirst = parts[len(parts)-1] + ", " + strings.Join(parts[:len(parts)-1], " ")
+		}
+
+		exclusive := goodreadsExclusiveShelves[entry.Status]
+		shelves := append([]string{exclusive}, shelvesByBook[book.ID]...)
+		positions := make([]string, len(shelves))
+		for i, shelf := range shelves {
+			positions[i] = shelf + " (#" + strconv.Itoa(i+1) + ")"
+		}
+
+		// Goodreads ratings are whole stars; half stars count towards the star below
+		rating, review, spoiler := "0", "", ""
+		if r, ok := reviewByBook[book.ID]; ok {
+			rating = strconv.Itoa(int(math.Floor(r.Rating)))
+			review = r.Body
+			if r.ContainsSpoilers {
+				spoiler = "true"
+			}
+		}
+		year := ""
+		if book.PublicationYear != nil {
+			year = strconv.Itoa(*book.PublicationYear)
+		}
+		readCount := "0"
+		if entry.Status == models.ShelfFinished {
+			readCount = "1"
+		}
+		added := entry.CreatedAt
+
+		// Titles, names, shelves and reviews are user-written and must not run as formulas
+		writer.Write([]string{
+			strconv.Itoa(int(book.ID)), escapeCSVCell(book.Name), escapeCSVCell(author), escapeCSVCell(authorLastFirst), "",
+			quoteISBN(book.ISBN10), quoteISBN(book.ISBN13), rating,
+			strconv.FormatFloat(book.RatingAverage, 'f', 2, 64), escapeCSVCell(book.Publisher), "",
+			strconv.Itoa(book.Page), year, year, formatDate(entry.FinishedAt), formatDate(&added),
+			escapeCSVCell(strings.Join(shelves, ", ")), escapeCSVCell(strings.Join(positions, ", ")), exclusive,
+			escapeCSVCell(review), spoiler, "", readCount, "0",
+		})
+	}
+	writer.Flush()
+
+	fmt.Printf("[ExportGoodreads] userID=%d, books=%d\n", userID, len(progress))
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="goodreads_library_export.csv"`)
+	return c.Send(out.Bytes())
+}
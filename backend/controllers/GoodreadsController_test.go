@@ -0,0 +1,48 @@
+package controllers
+
+import "testing"
+
+func TestEscapeCSVCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Kuyucaklı Yusuf", "Kuyucaklı Yusuf"},
+		{"", ""},
+		{`=HYPERLINK("http://example.com","x")`, `'=HYPERLINK("http://example.com","x")`},
+		{"+90 Şiirler", "'+90 Şiirler"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"a=b", "a=b"},
+		{"'quoted'", "'quoted'"},
+	}
+	for _, tt := range tests {
+		got := escapeCSVCell(tt.value)
+		if got != tt.want {
+			t.Errorf("escapeCSVCell(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+		if back := unescapeCSVCell(got); back != tt.value {
+			t.Errorf("unescapeCSVCell(%q) = %q, want %q", got, back, tt.value)
+		}
+	}
+}
+
+func TestParseGoodreadsCSVUnescapesFormulas(t *testing.T) {
+	data := []byte("Title,Author,ISBN13,Exclusive Shelf,My Review\n" +
+		`'=1+1,'@Şair,"=""9780306406157""",read,'-harika` + "\n")
+	rows, err := parseGoodreadsCSV(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("parseGoodreadsCSV() = %d rows, want 1", len(rows))
+	}
+	row := rows[0]
+	if row.result.Title != "=1+1" || row.result.Author != "@Şair" || row.review != "-harika" {
+		t.Errorf("parseGoodreadsCSV() = title %q, author %q, review %q", row.result.Title, row.result.Author, row.review)
+	}
+	if row.isbn13 != "9780306406157" {
+		t.Errorf("parseGoodreadsCSV() isbn13 = %q, want 9780306406157", row.isbn13)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"backend/database"
 	"backend/helpers"
 	"backend/models"
+	"backend/security"
 	"errors"
 	"strconv"
 
@@ -71,6 +72,7 @@ func CreateHomepageItem(c *fiber.Ctx) error {
 		})
 	}
 
+	sanitizeHomepage(&homepage)
 	database.DB.Create(&homepage)
 
 	var homepages []models.Homepage
@@ -103,6 +105,7 @@ func UpdateHomepageItem(c *fiber.Ctx) error {
 		})
 	}
 
+	sanitizeHomepage(&homepage)
 	database.DB.Model(&homepage).Updates(homepage)
 
 	var homepages []models.Homepage
@@ -136,3 +139,12 @@ func DeleteHomepageItem(c *fiber.Ctx) error {
 
 	return c.JSON(homepages)
 }
+
+// sanitizeHomepage keeps only Quill formatting in every field. The title and subtitle are
+// rendered as HTML too, so they go through the allowlist after the length limit.
+func sanitizeHomepage(homepage *models.Homepage) {
+	sanitizer := security.NewSanitizer()
+	homepage.Title = sanitizer.SanitizeRichText(sanitizer.SanitizeString(homepage.Title, 255))
+	homepage.Subtitle = sanitizer.SanitizeRichText(sanitizer.SanitizeString(homepage.Subtitle, 255))
+	homepage.Content = sanitizer.SanitizeRichText(homepage.Content)
+}
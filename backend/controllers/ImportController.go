@@ -0,0 +1,809 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	ImportKindBooks = "books"
+	ImportKindPoems = "poems"
+
+	// Row statuses in an import report
+	ImportRowCreate    = "create"
+	ImportRowDuplicate = "duplicate"
+	ImportRowInvalid   = "invalid"
+)
+
+// ImportFile is one uploaded or local file to import
+type ImportFile struct {
+	Name string
+	Data []byte
+}
+
+// ImportOptions controls a bulk import
+type ImportOptions struct {
+	DryRun        bool // validate and report without writing
+	CreateAuthors bool // create authors that match no existing author
+}
+
+// ImportRowResult is the outcome of one row (a CSV line, a JSON object or a Markdown file)
+type ImportRowResult struct {
+	Source      string   `json:"source"`
+	Row         int      `json:"row"`
+	Title       string   `json:"title"`
+	Slug        string   `json:"slug"`
+	Status      string   `json:"status"`
+	ID          uint     `json:"id,omitempty"`
+	AuthorID    *uint    `json:"author_id,omitempty"`
+	NewAuthor   string   `json:"new_author,omitempty"`
+	DuplicateOf uint     `json:"duplicate_of,omitempty"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// ImportReport summarizes a bulk import. Nothing is written unless every row is valid;
+// duplicates are skipped so an import can be run again safely.
+type ImportReport struct {
+	Kind       string            `json:"kind"`
+	DryRun     bool              `json:"dry_run"`
+	Committed  bool              `json:"committed"`
+	Total      int               `json:"total"`
+	Created    int               `json:"created"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Rows       []ImportRowResult `json:"rows"`
+}
+
+// bookImportRow is a book as read from CSV or JSON
+type bookImportRow struct {
+	Name            string   `json:"name"`
+	Author          string   `json:"author"`
+	Page            int      `json:"page"`
+	Image           string   `json:"image"`
+	Community       int      `json:"community"`
+	ISBN10          string   `json:"isbn10"`
+	ISBN13          string   `json:"isbn13"`
+	Publisher       string   `json:"publisher"`
+	PublicationYear *int     `json:"publication_year"`
+	Language        string   `json:"language"`
+	Translator      string   `json:"translator"`
+	Genres          []string `json:"genres"`
+	Series          string   `json:"series"`
+	SeriesPosition  *float64 `json:"series_position"`
+}
+
+// poemImportRow is a poem as read from Markdown with front matter
+type poemImportRow struct {
+	Title     string
+	Author    string
+	Tags      []string
+	Community int
+	Content   string
+}
+
+// importRow pairs a parsed row with its place in the report
+type importRow struct {
+	result ImportRowResult
+	book   bookImportRow
+	poem   poemImportRow
+}
+
+// importAuthors matches author names to existing authors by slug or normalized name and
+// plans the authors an import will create
+type importAuthors struct {
+	bySlug  map[string]models.Author
+	byName  map[string]models.Author
+	planned map[string]string // slug -> name of authors to create
+	create  bool
+}
+
+func loadImportAuthors(create bool) *importAuthors {
+	var authors []models.Author
+	database.DB.Select("id", "name", "slug").Where("is_deleted = ?", false).Find(&authors)
+
+	matcher := &importAuthors{
+		bySlug:  map[string]models.Author{},
+		byName:  map[string]models.Author{},
+		planned: map[string]string{},
+		create:  create,
+	}
+	for _, author := range authors {
+		matcher.bySlug[author.Slug] = author
+		matcher.byName[helpers.NormalizeName(author.Name)] = author
+	}
+	return matcher
+}
+
+// match fills the author of a row, or records an error when the author is unknown and
+// authors are not created
+func (a *importAuthors) match(name string, result *ImportRowResult) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	if author, ok := a.bySlug[helpers.Slugify(name)]; ok {
+		result.AuthorID = &author.ID
+		return author.Name
+	}
+	if author, ok := a.byName[helpers.NormalizeName(name)]; ok {
+		result.AuthorID = &author.ID
+		return author.Name
+	}
+	if !a.create {
+		result.Errors = append(result.Errors, fmt.Sprintf("author: %q not found", name))
+		return name
+	}
+	slug := helpers.Slugify(name)
+	if _, ok := a.planned[slug]; !ok {
+		a.planned[slug] = name
+	}
+	result.NewAuthor = a.planned[slug]
+	return a.planned[slug]
+}
+
+// importContentHash identifies content regardless of markup, case and punctuation
+func importContentHash(parts ...string) string {
+	normalized := make([]string, len(parts))
+	for i, part := range parts {
+		normalized[i] = helpers.NormalizeName(helpers.StripHTML(part))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(normalized, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// importDuplicates tracks slugs and content hashes of stored and already accepted rows
+type importDuplicates struct {
+	slugs  map[string]uint
+	hashes map[string]uint
+	isbns  map[string]uint
+}
+
+// find reports whether a row duplicates stored content or a row accepted earlier in the same
+// import; DuplicateOf is the stored id, or 0 for a row of this import
+func (d *importDuplicates) find(slug string, hash string, isbn string, result *ImportRowResult) bool {
+	if id, ok := d.slugs[slug]; ok {
+		result.DuplicateOf = id
+		return true
+	}
+	if id, ok := d.hashes[hash]; ok {
+		result.DuplicateOf = id
+		return true
+	}
+	if id, ok := d.isbns[isbn]; ok && isbn != "" {
+		result.DuplicateOf = id
+		return true
+	}
+	return false
+}
+
+func (d *importDuplicates) add(slug string, hash string, isbn string) {
+	d.slugs[slug] = 0
+	d.hashes[hash] = 0
+	if isbn != "" {
+		d.isbns[isbn] = 0
+	}
+}
+
+func loadBookDuplicates() *importDuplicates {
+	var books []struct {
+		ID         uint
+		Slug       string
+		Name       string
+		ISBN13     string `gorm:"column:isbn13"`
+		AuthorName string
+	}
+	database.DB.Table("books").
+		Select("books.id, books.slug, books.name, books.isbn13, COALESCE(authors.name, books.author, '') AS author_name").
+		Joins("LEFT JOIN authors ON authors.id = books.author_id").
+		Where("books.is_deleted = ?", false).
+		Scan(&books)
+
+	duplicates := &importDuplicates{slugs: map[string]uint{}, hashes: map[string]uint{}, isbns: map[string]uint{}}
+	for _, book := range books {
+		duplicates.slugs[book.Slug] = book.ID
+		duplicates.hashes[importContentHash(book.Name, book.AuthorName)] = book.ID
+		if book.ISBN13 != "" {
+			duplicates.isbns[book.ISBN13] = book.ID
+		}
+	}
+	return duplicates
+}
+
+func loadPoemDuplicates() *importDuplicates {
+	var poems []models.Poem
+	database.DB.Select("id", "slug", "content").Where("is_deleted = ?", false).Find(&poems)
+
+	duplicates := &importDuplicates{slugs: map[string]uint{}, hashes: map[string]uint{}, isbns: map[string]uint{}}
+	for _, poem := range poems {
+		duplicates.slugs[poem.Slug] = poem.ID
+		duplicates.hashes[importContentHash(poem.Content)] = poem.ID
+	}
+	return duplicates
+}
+
+// parseBookCSV reads books from CSV with a header row. Column names match the JSON keys;
+// genres are separated by ";".
+func parseBookCSV(file ImportFile) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(file.Data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read header: %v", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("header must contain a name column")
+	}
+
+	var rows []importRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row := importRow{result: ImportRowResult{Source: file.Name, Row: line}}
+		if err != nil {
+			row.result.Errors = append(row.result.Errors, err.Error())
+			rows = append(rows, row)
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		getInt := func(name string) *int {
+			value := get(name)
+			if value == "" {
+				return nil
+			}
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				row.result.Errors = append(row.result.Errors, fmt.Sprintf("%s: %q is not a number", name, value))
+				return nil
+			}
+			return &number
+		}
+
+		book := bookImportRow{
+			Name:       get("name"),
+			Author:     get("author"),
+			Image:      get("image"),
+			ISBN10:     get("isbn10"),
+			ISBN13:     get("isbn13"),
+			Publisher:  get("publisher"),
+			Language:   get("language"),
+			Translator: get("translator"),
+			Series:     get("series"),
+		}
+		if page := getInt("page"); page != nil {
+			book.Page = *page
+		}
+		if community := getInt("community"); community != nil {
+			book.Community = *community
+		}
+		book.PublicationYear = getInt("publication_year")
+		if position := get("series_position"); position != "" {
+			value, err := strconv.ParseFloat(position, 64)
+			if err != nil {
+				row.result.Errors = append(row.result.Errors, fmt.Sprintf("series_position: %q is not a number", position))
+			} else {
+				book.SeriesPosition = &value
+			}
+		}
+		for _, genre := range strings.Split(get("genres"), ";") {
+			if genre = strings.TrimSpace(genre); genre != "" {
+				book.Genres = append(book.Genres, genre)
+			}
+		}
+
+		row.book = book
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseBookJSON reads books from a JSON array of objects
+func parseBookJSON(file ImportFile) ([]importRow, error) {
+	var books []bookImportRow
+	if err := json.Unmarshal(file.Data, &books); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	rows := make([]importRow, len(books))
+	for i, book := range books {
+		rows[i] = importRow{result: ImportRowResult{Source: file.Name, Row: i + 1}, book: book}
+	}
+	return rows, nil
+}
+
+var (
+	markdownBold   = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	markdownItalic = regexp.MustCompile(`\*(.+?)\*`)
+)
+
+// parsePoemMarkdown reads a poem from Markdown with YAML-style front matter:
+//
+//	---
+//	title: Sessizlik
+//	author: Cemal Süreya
+//	tags: [aşk, gece]
+//	community: 2
+//	---
+//	first line
+//	second line
+//
+// Each line becomes a paragraph and blank lines separate stanzas, as in the editor.
+// **bold** and *italic* are kept.
+func parsePoemMarkdown(file ImportFile) (poemImportRow, error) {
+	text := strings.ReplaceAll(strings.TrimPrefix(string(file.Data), "\ufeff"), "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return poemImportRow{}, errors.New("missing front matter")
+	}
+
+	poem := poemImportRow{}
+	end := -1
+	listKey := ""
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "---" {
+			end = i
+			break
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "- ") && listKey == "tags" {
+			poem.Tags = append(poem.Tags, unquoteFrontMatter(strings.TrimPrefix(line, "- ")))
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return poemImportRow{}, fmt.Errorf("front matter line %d: expected key: value", i+1)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		listKey = key
+		switch key {
+		case "title":
+			poem.Title = unquoteFrontMatter(value)
+		case "author":
+			poem.Author = unquoteFrontMatter(value)
+		case "tags":
+			value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			for _, tag := range strings.Split(value, ",") {
+				if tag = unquoteFrontMatter(tag); tag != "" {
+					poem.Tags = append(poem.Tags, tag)
+				}
+			}
+		case "community":
+			community, err := strconv.Atoi(value)
+			if err != nil {
+				return poemImportRow{}, fmt.Errorf("community: %q is not a number", value)
+			}
+			poem.Community = community
+		}
+	}
+	if end == -1 {
+		return poemImportRow{}, errors.New("front matter is not closed with ---")
+	}
+
+	var content strings.Builder
+	body := strings.TrimSpace(strings.Join(lines[end+1:], "\n"))
+	if body != "" {
+		for _, line := range strings.Split(body, "\n") {
+			line = strings.TrimRight(line, " \t")
+			if line == "" {
+				content.WriteString("<p><br></p>")
+				continue
+			}
+			line = html.EscapeString(line)
+			line = markdownBold.ReplaceAllString(line, "<strong>$1</strong>")
+			line = markdownItalic.ReplaceAllString(line, "<em>$1</em>")
+			content.WriteString("<p>" + line + "</p>")
+		}
+	}
+	poem.Content = content.String()
+	return poem, nil
+}
+
+func unquoteFrontMatter(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return strings.TrimSpace(value)
+}
+
+// parseImportFiles turns the files of an import into rows. A file that cannot be read at all
+// becomes a single invalid row so it still blocks the commit.
+func parseImportFiles(kind string, files []ImportFile) []importRow {
+	var rows []importRow
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Name))
+		var parsed []importRow
+		var err error
+		switch {
+		case kind == ImportKindBooks && ext == ".csv":
+			parsed, err = parseBookCSV(file)
+		case kind == ImportKindBooks && ext == ".json":
+			parsed, err = parseBookJSON(file)
+		case kind == ImportKindPoems && (ext == ".md" || ext == ".markdown"):
+			var poem poemImportRow
+			poem, err = parsePoemMarkdown(file)
+			parsed = []importRow{{result: ImportRowResult{Source: file.Name, Row: 1}, poem: poem}}
+		default:
+			err = fmt.Errorf("unsupported file type %q for %s", ext, kind)
+		}
+		if err != nil {
+			rows = append(rows, importRow{result: ImportRowResult{
+				Source: file.Name,
+				Status: ImportRowInvalid,
+				Errors: []string{err.Error()},
+			}})
+			continue
+		}
+		rows = append(rows, parsed...)
+	}
+	return rows
+}
+
+// validateBookImportRow checks a book row like CreateBook does and returns the book to create
+func validateBookImportRow(row *importRow, authors *importAuthors, duplicates *importDuplicates) models.Book {
+	validator := security.NewValidator()
+	result := &row.result
+	input := row.book
+
+	if err := validator.ValidateString("name", input.Name, 1, 255, true); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	if input.Community == 0 {
+		input.Community = 1
+	}
+	if err := validator.ValidateCommunity(input.Community); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	if input.Page < 0 {
+		result.Errors = append(result.Errors, "page: must not be negative")
+	}
+
+	book := models.Book{
+		Name:            security.NewSanitizer().SanitizeString(input.Name, 255),
+		Image:           security.NewSanitizer().SanitizeString(input.Image, 1000),
+		Page:            input.Page,
+		Community:       input.Community,
+		ISBN10:          input.ISBN10,
+		ISBN13:          input.ISBN13,
+		Publisher:       input.Publisher,
+		PublicationYear: input.PublicationYear,
+		Language:        input.Language,
+		Translator:      input.Translator,
+		SeriesPosition:  input.SeriesPosition,
+	}
+	if err := validateBookMetadata(&book); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	book.Author = authors.match(input.Author, result)
+	book.AuthorID = result.AuthorID
+	book.Slug = helpers.Slugify(book.Name)
+	result.Title = book.Name
+	result.Slug = book.Slug
+
+	if len(result.Errors) == 0 {
+		hash := importContentHash(book.Name, book.Author)
+		if duplicates.find(book.Slug, hash, book.ISBN13, result) {
+			result.Status = ImportRowDuplicate
+		} else {
+			duplicates.add(book.Slug, hash, book.ISBN13)
+		}
+	}
+	return book
+}
+
+// validatePoemImportRow checks a poem row like CreatePoem does and returns the poem to create
+func validatePoemImportRow(row *importRow, authors *importAuthors, duplicates *importDuplicates) models.Poem {
+	validator := security.NewValidator()
+	sanitizer := security.NewSanitizer()
+	result := &row.result
+	input := row.poem
+
+	if err := validator.ValidateString("title", input.Title, 1, 255, true); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	if sanitizer.ContainsDangerousContent(input.Title) {
+		result.Errors = append(result.Errors, "title: contains potentially dangerous elements")
+	}
+	if input.Community == 0 {
+		input.Community = 1
+	}
+	if err := validator.ValidateCommunity(input.Community); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	poem := models.Poem{
+		Title:     sanitizer.SanitizeString(input.Title, 255),
+		Content:   sanitizer.SanitizeRichText(input.Content),
+		Community: input.Community,
+	}
+	if len(poem.Content) > 50000 {
+		result.Errors = append(result.Errors, "content: is too long")
+	} else if sanitizer.SanitizePlainText(poem.Content) == "" {
+		result.Errors = append(result.Errors, "content: is required")
+	}
+	for _, tag := range input.Tags {
+		poem.Tags = append(poem.Tags, models.Tag{Name: tag})
+	}
+	poem.Author = authors.match(input.Author, result)
+	poem.AuthorID = result.AuthorID
+	poem.Slug = helpers.Slugify(poem.Title)
+	result.Title = poem.Title
+	result.Slug = poem.Slug
+
+	if len(result.Errors) == 0 {
+		hash := importContentHash(poem.Content)
+		if duplicates.find(poem.Slug, hash, "", result) {
+			result.Status = ImportRowDuplicate
+		} else {
+			duplicates.add(poem.Slug, hash, "")
+		}
+	}
+	return poem
+}
+
+// createImportAuthors creates the planned authors and points the rows at them
+func createImportAuthors(tx *gorm.DB, authors *importAuthors, rows []importRow) error {
+	created := map[string]uint{}
+	for slug, name := range authors.planned {
+		author := models.Author{
+			Name:      name,
+			Slug:      slug,
+			CreatedAt: time.Now().Format("02-01-2006"),
+		}
+		if err := tx.Create(&author).Error; err != nil {
+			return err
+		}
+		created[slug] = author.ID
+	}
+	for i := range rows {
+		if rows[i].result.NewAuthor != "" {
+			id := created[helpers.Slugify(rows[i].result.NewAuthor)]
+			rows[i].result.AuthorID = &id
+		}
+	}
+	return nil
+}
+
+// RunImport validates the files of a bulk import and, unless it is a dry run or a row is
+// invalid, creates every new row in one transaction. It only returns an error when the
+// transaction fails; validation problems are reported per row.
+func RunImport(kind string, files []ImportFile, options ImportOptions) (ImportReport, error) {
+	report := ImportReport{Kind: kind, DryRun: options.DryRun, Rows: []ImportRowResult{}}
+	if kind != ImportKindBooks && kind != ImportKindPoems {
+		return report, fmt.Errorf("unknown import kind %q", kind)
+	}
+
+	rows := parseImportFiles(kind, files)
+	if maxRows := helpers.GetEnvInt("IMPORT_MAX_ROWS", 2000); len(rows) > maxRows {
+		return report, fmt.Errorf("import has %d rows, the limit is %d", len(rows), maxRows)
+	}
+
+	authors := loadImportAuthors(options.CreateAuthors)
+	books := make([]models.Book, len(rows))
+	poems := make([]models.Poem, len(rows))
+	var duplicates *importDuplicates
+	if kind == ImportKindBooks {
+		duplicates = loadBookDuplicates()
+	} else {
+		duplicates = loadPoemDuplicates()
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if row.result.Status == ImportRowInvalid {
+			continue
+		}
+		if kind == ImportKindBooks {
+			books[i] = validateBookImportRow(row, authors, duplicates)
+		} else {
+			poems[i] = validatePoemImportRow(row, authors, duplicates)
+		}
+		switch {
+		case len(row.result.Errors) > 0:
+			row.result.Status = ImportRowInvalid
+		case row.result.Status == "":
+			row.result.Status = ImportRowCreate
+		}
+	}
+
+	for _, row := range rows {
+		report.Total++
+		switch row.result.Status {
+		case ImportRowInvalid:
+			report.Invalid++
+		case ImportRowDuplicate:
+			report.Duplicates++
+		}
+	}
+
+	if !options.DryRun && report.Invalid == 0 {
+		err := database.DB.Transaction(func(tx *gorm.DB) error {
+			if err := createImportAuthors(tx, authors, rows); err != nil {
+				return err
+			}
+			now := time.Now()
+			for i := range rows {
+				if rows[i].result.Status != ImportRowCreate {
+					continue
+				}
+				if kind == ImportKindBooks {
+					id, err := createImportedBook(tx, books[i], rows[i], now)
+					if err != nil {
+						return fmt.Errorf("%s row %d: %w", rows[i].result.Source, rows[i].result.Row, err)
+					}
+					rows[i].result.ID = id
+				} else {
+					id, err := createImportedPoem(tx, poems[i], rows[i], now)
+					if err != nil {
+						return fmt.Errorf("%s row %d: %w", rows[i].result.Source, rows[i].result.Row, err)
+					}
+					rows[i].result.ID = id
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return report, err
+		}
+		report.Committed = true
+
+		if kind == ImportKindPoems {
+			for _, row := range rows {
+				if row.result.ID != 0 {
+					indexPoemContent(row.result.ID)
+				}
+			}
+		}
+	}
+
+	for _, row := range rows {
+		if row.result.Status == ImportRowCreate {
+			report.Created++
+		}
+		report.Rows = append(report.Rows, row.result)
+	}
+	return report, nil
+}
+
+func createImportedBook(tx *gorm.DB, book models.Book, row importRow, now time.Time) (uint, error) {
+	book.AuthorID = row.result.AuthorID
+	book.CreatedAt = now.Format("02-01-2006")
+	if row.book.Series != "" {
+		if err := resolveSeries(tx, &book, &models.Series{Name: row.book.Series}); err != nil {
+			return 0, err
+		}
+	}
+	if err := ensureBookWork(tx, &book); err != nil {
+		return 0, err
+	}
+	genres := make([]models.Genre, len(row.book.Genres))
+	for i, name := range row.book.Genres {
+		genres[i] = models.Genre{Name: name}
+	}
+	resolved, err := resolveGenres(tx, genres)
+	if err != nil {
+		return 0, err
+	}
+	book.Genres = resolved
+	book.Contributors = nil
+	if err := tx.Create(&book).Error; err != nil {
+		return 0, err
+	}
+	if err := syncPrimaryContributor(tx, "book_id", book.ID, book.AuthorID); err != nil {
+		return 0, err
+	}
+	return book.ID, nil
+}
+
+func createImportedPoem(tx *gorm.DB, poem models.Poem, row importRow, now time.Time) (uint, error) {
+	poem.AuthorID = row.result.AuthorID
+	poem.CreatedAt = now.Format("02-01-2006")
+	poem.CreatedAtParse = now.String()
+	tags, err := resolveTags(tx, poem.Tags)
+	if err != nil {
+		return 0, err
+	}
+	poem.Tags = tags
+	poem.Contributors = nil
+	if err := tx.Create(&poem).Error; err != nil {
+		return 0, err
+	}
+	if err := syncPrimaryContributor(tx, "poem_id", poem.ID, poem.AuthorID); err != nil {
+		return 0, err
+	}
+	return poem.ID, nil
+}
+
+// ImportContent bulk imports books (CSV or JSON) or poems (Markdown with front matter)
+// from the uploaded "files". ?dry_run=true only reports, ?create_authors=true creates
+// authors that match no existing one.
+func ImportContent(c *fiber.Ctx) error {
+	kind := c.Params("kind")
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Expected a multipart form with files",
+		})
+	}
+
+	var files []ImportFile
+	for _, header := range append(form.File["files"], form.File["file"]...) {
+		file, err := header.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Could not read " + header.Filename,
+			})
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Could not read " + header.Filename,
+			})
+		}
+		files = append(files, ImportFile{Name: filepath.Base(header.Filename), Data: data})
+	}
+	if len(files) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No files uploaded",
+		})
+	}
+
+	options := ImportOptions{
+		DryRun:        c.Query("dry_run") == "true",
+		CreateAuthors: c.Query("create_authors") == "true",
+	}
+	report, err := RunImport(kind, files, options)
+	fmt.Printf("[ImportContent] kind=%s, files=%d, dry_run=%t, total=%d, invalid=%d, duplicates=%d, committed=%t\n",
+		kind, len(files), options.DryRun, report.Total, report.Invalid, report.Duplicates, report.Committed)
+	if err != nil {
+		if report.Total == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":  "Import failed, nothing was saved: " + err.Error(),
+			"report": report,
+		})
+	}
+	if report.Invalid > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(report)
+	}
+	return c.JSON(report)
+}
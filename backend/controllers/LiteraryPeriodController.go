@@ -0,0 +1,365 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// periodAuthorsSQL selects the authors of a period by slug, for "author_id IN (...)" filters
+const periodAuthorsSQL = `SELECT author_periods.author_id FROM author_periods
+	JOIN literary_periods ON literary_periods.id = author_periods.literary_period_id
+	WHERE literary_periods.slug = ?`
+
+// applyPeriodFilter limits a query to rows whose author belongs to the ?period= slug.
+// column is the author id column of the queried table.
+func applyPeriodFilter(query *gorm.DB, c *fiber.Ctx, column string) *gorm.DB {
+	if period := c.Query("period"); period != "" {
+		return query.Where(column+" IN ("+periodAuthorsSQL+")", period)
+	}
+	return query
+}
+
+// resolvePeriods maps the periods sent with an author to stored periods by id or slug.
+// Unlike tags, unknown periods are an error: periods carry dates and are curated.
+func resolvePeriods(tx *gorm.DB, input []models.LiteraryPeriod) ([]models.LiteraryPeriod, error) {
+	periods := []models.LiteraryPeriod{}
+	seen := map[uint]bool{}
+	for _, item := range input {
+		var period models.LiteraryPeriod
+		var err error
+		switch {
+		case item.ID != 0:
+			err = tx.First(&period, item.ID).Error
+		case item.Slug != "":
+			err = tx.Where("slug = ?", item.Slug).First(&period).Error
+		default:
+			err = tx.Where("slug = ?", helpers.Slugify(strings.TrimSpace(item.Name))).First(&period).Error
+		}
+		if err != nil {
+			label := item.Slug
+			if label == "" {
+				label = item.Name
+			}
+			if item.ID != 0 {
+				label = strconv.Itoa(int(item.ID))
+			}
+			return nil, &security.ValidationError{Field: "periods", Message: fmt.Sprintf("unknown period %q", label)}
+		}
+		if !seen[period.ID] {
+			seen[period.ID] = true
+			periods = append(periods, period)
+		}
+	}
+	return periods, nil
+}
+
+// validateLiteraryPeriod cleans and checks a period before it is saved
+func validateLiteraryPeriod(period *models.LiteraryPeriod) error {
+	sanitizer := security.NewSanitizer()
+	period.Name = strings.TrimSpace(sanitizer.SanitizePlainText(period.Name))
+	period.Description = sanitizer.SanitizePlainText(period.Description)
+	if err := security.NewValidator().ValidateString("name", period.Name, 1, 100, true); err != nil {
+		return err
+	}
+	if period.Kind == "" {
+		period.Kind = models.LiteraryPeriodEra
+	}
+	if period.Kind != models.LiteraryPeriodEra && period.Kind != models.LiteraryPeriodMovement {
+		return &security.ValidationError{Field: "kind", Message: "must be period or movement"}
+	}
+	if period.StartYear != nil && period.EndYear != nil && *period.EndYear < *period.StartYear {
+		return &security.ValidationError{Field: "end_year", Message: "must not be before start_year"}
+	}
+	period.Slug = helpers.Slugify(period.Name)
+	return nil
+}
+
+// literaryPeriodError writes the response for a failed period save
+func literaryPeriodError(c *fiber.Ctx, err error) error {
+	var validationErr *security.ValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": validationErr.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to save literary period",
+	})
+}
+
+// GetLiteraryPeriods lists periods and movements chronologically with their author counts.
+// ?kind=period|movement filters by kind.
+func GetLiteraryPeriods(c *fiber.Ctx) error {
+	query := database.DB.Model(&models.LiteraryPeriod{})
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	periods := []models.LiteraryPeriod{}
+	query.Order("start_year ASC NULLS LAST, name ASC").Find(&periods)
+
+	var counts []struct {
+		LiteraryPeriodID uint
+		Count            int64
+	}
+	database.DB.Table("author_periods").
+		Select("author_periods.literary_period_id, COUNT(*) AS count").
+		Joins("JOIN authors ON authors.id = author_periods.author_id").
+		Where("authors.is_deleted = ?", false).
+		Group("author_periods.literary_period_id").
+		Scan(&counts)
+	countByPeriod := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countByPeriod[count.LiteraryPeriodID] = count.Count
+	}
+	for i := range periods {
+		periods[i].AuthorCount = countByPeriod[periods[i].ID]
+	}
+
+	return c.JSON(periods)
+}
+
+// GetLiteraryPeriod returns a period with its authors, oldest first
+func GetLiteraryPeriod(c *fiber.Ctx) error {
+	var period models.LiteraryPeriod
+	if err := database.DB.Where("slug = ?", c.Params("slug")).
+		Preload("Authors", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_deleted = ?", false).Order("birth_year ASC NULLS LAST, name ASC")
+		}).
+		First(&period).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Literary period not found",
+		})
+	}
+	period.AuthorCount = int64(len(period.Authors))
+	attachAuthorFollows(period.Authors, GetUserId(c))
+	return c.JSON(period)
+}
+
+// CreateLiteraryPeriod adds a period or movement
+func CreateLiteraryPeriod(c *fiber.Ctx) error {
+	var period models.LiteraryPeriod
+	if err := c.BodyParser(&period); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	period.ID = 0
+	period.Authors = nil
+	if err := validateLiteraryPeriod(&period); err != nil {
+		return literaryPeriodError(c, err)
+	}
+
+	var taken int64
+	database.DB.Model(&models.LiteraryPeriod{}).Where("slug = ?", period.Slug).Count(&taken)
+	if taken > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "A literary period with this name already exists",
+		})
+	}
+	if err := database.DB.Create(&period).Error; err != nil {
+		return literaryPeriodError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(period)
+}
+
+// UpdateLiteraryPeriod replaces a period's name, kind, years and description
+func UpdateLiteraryPeriod(c *fiber.Ctx) error {
+	id, _ := strconv.Atoi(c.Params("id"))
+
+	var period models.LiteraryPeriod
+	if err := database.DB.First(&period, id).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Literary period not found",
+		})
+	}
+	var updateData models.LiteraryPeriod
+	if err := c.BodyParser(&updateData); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	updateData.ID = period.ID
+	updateData.Authors = nil
+	if err := validateLiteraryPeriod(&updateData); err != nil {
+		return literaryPeriodError(c, err)
+	}
+
+	var taken int64
+	database.DB.Model(&models.LiteraryPeriod{}).Where("slug = ? AND id <> ?", updateData.Slug, period.ID).Count(&taken)
+	if taken > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "A literary period with this name already exists",
+		})
+	}
+	if err := database.DB.Save(&updateData).Error; err != nil {
+		return literaryPeriodError(c, err)
+	}
+	return c.JSON(updateData)
+}
+
+// DeleteLiteraryPeriod deletes a period and unlinks its authors
+func DeleteLiteraryPeriod(c *fiber.Ctx) error {
+	id, _ := strconv.Atoi(c.Params("id"))
+
+	var period models.LiteraryPeriod
+	if err := database.DB.First(&period, id).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Literary period not found",
+		})
+	}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&period).Association("Authors").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(&period).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete literary period",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Literary period deleted successfully",
+	})
+}
+
+// TimelineWork is a dated work of an author on the timeline
+type TimelineWork struct {
+	WorkID uint   `json:"work_id"`
+	BookID uint   `json:"book_id"` // An edition to link to
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	Year   *int   `json:"year"`
+}
+
+// TimelineAuthor is an author on the timeline with their works in publication order
+type TimelineAuthor struct {
+	ID        uint           `json:"id"`
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	Image     string         `json:"image"`
+	BirthYear *int           `json:"birth_year"`
+	DeathYear *int           `json:"death_year"`
+	PoemCount int64          `json:"poem_count"`
+	Works     []TimelineWork `json:"works"`
+}
+
+// TimelinePeriod is a period with its authors in birth order
+type TimelinePeriod struct {
+	models.LiteraryPeriod
+	TimelineAuthors []TimelineAuthor `json:"timeline_authors"`
+}
+
+// compareYears orders known years first, ascending
+func compareYears(a, b *int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return *a - *b
+}
+
+// GetLiteraryTimeline returns periods in chronological order, each with its authors ordered
+// by birth year and their works ordered by first publication year. ?period= limits it to one
+// period. Only content the viewer can see is counted.
+func GetLiteraryTimeline(c *fiber.Ctx) error {
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	query := database.DB.Model(&models.LiteraryPeriod{}).Preload("Authors", "is_deleted = ?", false)
+	if slug := c.Query("period"); slug != "" {
+		query = query.Where("slug = ?", slug)
+	}
+	var periods []models.LiteraryPeriod
+	query.Order("start_year ASC NULLS LAST, name ASC").Find(&periods)
+
+	authorIDs := []uint{}
+	for _, period := range periods {
+		for _, author := range period.Authors {
+			authorIDs = append(authorIDs, author.ID)
+		}
+	}
+
+	// One entry per work, dated by the work's first publication or its earliest edition
+	var works []struct {
+		WorkID   uint
+		BookID   uint
+		AuthorID uint
+		Title    string
+		Slug     string
+		Year     *int
+	}
+	poemCounts := map[uint]int64{}
+	if len(authorIDs) > 0 {
+		bookQuery := database.DB.Table("books").
+			Select("DISTINCT ON (books.work_id) books.work_id, books.id AS book_id, books.author_id, "+
+				"COALESCE(works.title, books.name) AS title, books.slug, "+
+				"COALESCE(works.first_published_year, books.publication_year) AS year").
+			Joins("JOIN works ON works.id = books.work_id").
+			Where("books.is_deleted = ? AND books.author_id IN ?", false, authorIDs)
+		applyCommunityFilterForBook(bookQuery, roleID).
+			Order("books.work_id, books.publication_year ASC NULLS LAST, books.id ASC").
+			Scan(&works)
+
+		var counts []struct {
+			AuthorID uint
+			Count    int64
+		}
+		applyCommunityFilter(database.DB.Model(&models.Poem{}), roleID).
+			Select("author_id, COUNT(*) AS count").
+			Where("is_deleted = ? AND author_id IN ?", false, authorIDs).
+			Group("author_id").
+			Scan(&counts)
+		for _, count := range counts {
+			poemCounts[count.AuthorID] = count.Count
+		}
+	}
+	worksByAuthor := map[uint][]TimelineWork{}
+	for _, work := range works {
+		worksByAuthor[work.AuthorID] = append(worksByAuthor[work.AuthorID], TimelineWork{
+			WorkID: work.WorkID, BookID: work.BookID, Title: work.Title, Slug: work.Slug, Year: work.Year,
+		})
+	}
+
+	timeline := make([]TimelinePeriod, len(periods))
+	for i, period := range periods {
+		authors := make([]TimelineAuthor, len(period.Authors))
+		for j, author := range period.Authors {
+			authorWorks := append([]TimelineWork{}, worksByAuthor[author.ID]...)
+			sort.SliceStable(authorWorks, func(a, b int) bool {
+				return compareYears(authorWorks[a].Year, authorWorks[b].Year) < 0
+			})
+			authors[j] = TimelineAuthor{
+				ID: author.ID, Name: author.Name, Slug: author.Slug, Image: author.Image,
+				BirthYear: author.BirthYear, DeathYear: author.DeathYear,
+				PoemCount: poemCounts[author.ID], Works: authorWorks,
+			}
+		}
+		sort.SliceStable(authors, func(a, b int) bool {
+			if order := compareYears(authors[a].BirthYear, authors[b].BirthYear); order != 0 {
+				return order < 0
+			}
+			return authors[a].Name < authors[b].Name
+		})
+		period.Authors = nil
+		timeline[i] = TimelinePeriod{LiteraryPeriod: period, TimelineAuthors: authors}
+	}
+
+	return c.JSON(timeline)
+}
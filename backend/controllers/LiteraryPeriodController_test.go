@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"backend/models"
+	"backend/security"
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestValidateLiteraryPeriod(t *testing.T) {
+	year := func(y int) *int { return &y }
+	tests := []struct {
+		name   string
+		period models.LiteraryPeriod
+		field  string // Empty when the period is valid
+		kind   string
+		slug   string
+	}{
+		{"defaults to era", models.LiteraryPeriod{Name: " Servet-i Fünun ", StartYear: year(1896), EndYear: year(1901)},
+			"", models.LiteraryPeriodEra, "servet-i-funun"},
+		{"movement", models.LiteraryPeriod{Name: "İkinci Yeni", Kind: models.LiteraryPeriodMovement, StartYear: year(1955)},
+			"", models.LiteraryPeriodMovement, "ikinci-yeni"},
+		{"same start and end year", models.LiteraryPeriod{Name: "Garip", StartYear: year(1941), EndYear: year(1941)},
+			"", models.LiteraryPeriodEra, "garip"},
+		{"missing name", models.LiteraryPeriod{Name: "   "}, "name", "", ""},
+		{"unknown kind", models.LiteraryPeriod{Name: "Tanzimat", Kind: "school"}, "kind", "", ""},
+		{"ends before it starts", models.LiteraryPeriod{Name: "Tanzimat", StartYear: year(1876), EndYear: year(1839)}, "end_year", "", ""},
+	}
+	for _, tt := range tests {
+		period := tt.period
+		err := validateLiteraryPeriod(&period)
+		if tt.field != "" {
+			var validationErr *security.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("%s: error = %v, want a validation error on %s", tt.name, err, tt.field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if period.Kind != tt.kind || period.Slug != tt.slug {
+			t.Errorf("%s: kind, slug = %q, %q, want %q, %q", tt.name, period.Kind, period.Slug, tt.kind, tt.slug)
+		}
+	}
+}
+
+func TestCompareYearsPutsUnknownYearsLast(t *testing.T) {
+	year := func(y int) *int { return &y }
+	years := []*int{nil, year(1923), year(1896), nil, year(1940)}
+	sort.SliceStable(years, func(i, j int) bool { return compareYears(years[i], years[j]) < 0 })
+
+	want := []int{1896, 1923, 1940}
+	for i, y := range want {
+		if years[i] == nil || *years[i] != y {
+			t.Fatalf("position %d = %v, want %d", i, years[i], y)
+		}
+	}
+	if years[3] != nil || years[4] != nil {
+		t.Errorf("unknown years are not last")
+	}
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	ws "backend/websocket"
+	"fmt"
+	"strings"
+)
+
+// maxMentionsPerComment caps how many users a single comment can notify
+const maxMentionsPerComment = 10
+
+// syncCommentMentions re-parses the comment content and replaces its mention records.
+// It returns the mentions of users who were not mentioned in the previous version.
+func syncCommentMentions(comment models.Comment) []models.CommentMention {
+	found := helpers.FindMentions(comment.Content)
+
+	usernames := make([]string, 0, len(found))
+	for _, mention := range found {
+		usernames = append(usernames, strings.ToLower(mention.Username))
+	}
+
+	users := make(map[string]models.Admin)
+	if len(usernames) > 0 {
+		var admins []models.Admin
+		database.DB.Select("id", "username").Where("LOWER(username) IN ?", usernames).Find(&admins)
+		for _, admin := range admins {
+			users[strings.ToLower(admin.Username)] = admin
+		}
+	}
+
+	var previous []models.CommentMention
+	database.DB.Where("comment_id = ?", comment.ID).Find(&previous)
+	previouslyMentioned := make(map[uint]bool, len(previous))
+	for _, mention := range previous {
+		previouslyMentioned[mention.MentionedID] = true
+	}
+
+	mentions := make([]models.CommentMention, 0, len(found))
+	mentionedUsers := make(map[uint]bool)
+	for _, mention := range found {
+		user, ok := users[strings.ToLower(mention.Username)]
+		if !ok {
+			continue
+		}
+		if !mentionedUsers[user.ID] && len(mentionedUsers) >= maxMentionsPerComment {
+			continue
+		}
+		mentionedUsers[user.ID] = true
+		mentions = append(mentions, models.CommentMention{
+			CommentID:   comment.ID,
+			MentionedID: user.ID,
+			Username:    user.Username,
+			SpanStart:   mention.Start,
+			SpanEnd:     mention.End,
+		})
+	}
+
+	database.DB.Where("comment_id = ?", comment.ID).Delete(&models.CommentMention{})
+	if len(mentions) > 0 {
+		if err := database.DB.Create(&mentions).Error; err != nil {
+			fmt.Printf("[Mentions] saving mentions of comment %d failed: %v\n", comment.ID, err)
+			return nil
+		}
+	}
+
+	added := make([]models.CommentMention, 0, len(mentions))
+	notified := make(map[uint]bool)
+	for _, mention := range mentions {
+		if previouslyMentioned[mention.MentionedID] || notified[mention.MentionedID] {
+			continue
+		}
+		notified[mention.MentionedID] = true
+		added = append(added, mention)
+	}
+	return added
+}
+
+// notifyCommentMentions notifies mentioned users who are allowed to see the comment.
+// Authors mentioning themselves are skipped.
+func notifyCommentMentions(comment models.Comment, mentions []models.CommentMention) {
+	if comment.Status != models.CommentStatusVisible || len(mentions) == 0 {
+		return
+	}
+
+	var author models.Admin
+	database.DB.Select("id", "username").First(&author, comment.AdminID)
+
+	for _, mention := range mentions {
+		if mention.MentionedID == comment.AdminID {
+			continue
+		}
+
+		var viewer models.Admin
+		if err := database.DB.Select("id", "role_id").First(&viewer, mention.MentionedID).Error; err != nil {
+			continue
+		}
+		if !canSeeComment(viewer, comment) {
+			fmt.Printf("[Mentions] user %d cannot see comment %d, not notifying\n", viewer.ID, comment.ID)
+			continue
+		}
+
+		fmt.Printf("[WebSocket] Sending comment_mention to user %d for comment %d\n", viewer.ID, comment.ID)
+		ws.GlobalHub.SendToUser(viewer.ID, "comment_mention", map[string]interface{}{
+			"comment_id":    comment.ID,
+			"book_id":       comment.BookID,
+			"poem_id":       comment.PoemID,
+			"from_user_id":  author.ID,
+			"from_username": author.Username,
+			"content":       comment.Content,
+		})
+	}
+}
+
+// canSeeComment reports whether the viewer would find the comment in its thread:
+// the comment and all of its ancestors pass the friendship rules and the viewer can see the book or poem
+func canSeeComment(viewer models.Admin, comment models.Comment) bool {
+	current := comment
+	for {
+		var visible int64
+		visibleCommentsQuery(viewer.ID, viewer.RoleID).
+			Model(&models.Comment{}).
+			Where("comments.id = ?", current.ID).
+			Count(&visible)
+		if visible == 0 {
+			return false
+		}
+		if current.ParentID == nil {
+			break
+		}
+		var parent models.Comment
+		if err := database.DB.First(&parent, *current.ParentID).Error; err != nil {
+			return false
+		}
+		current = parent
+	}
+
+	if comment.PoemID != nil {
+		_, err := findVisiblePoem(*comment.PoemID, viewer.RoleID)
+		return err == nil
+	}
+	if comment.BookID != nil {
+		var books int64
+		query := database.DB.Model(&models.Book{}).Where("id = ? AND is_deleted = ?", *comment.BookID, false)
+		applyCommunityFilterForBook(query, viewer.RoleID).Count(&books)
+		return books > 0
+	}
+	return false
+}
+
+// loadCommentMentions returns the mention spans of each comment, in text order
+func loadCommentMentions(commentIDs []uint) map[uint][]models.CommentMention {
+	mentions := make(map[uint][]models.CommentMention)
+	if len(commentIDs) == 0 {
+		return mentions
+	}
+
+	var rows []models.CommentMention
+	database.DB.Where("comment_id IN ?", commentIDs).Order("comment_id, span_start").Find(&rows)
+	for _, row := range rows {
+		mentions[row.CommentID] = append(mentions[row.CommentID], row)
+	}
+	return mentions
+}
@@ -4,6 +4,7 @@ import (
 	"backend/database"
 	"backend/helpers"
 	"backend/models"
+	"backend/security"
 	"errors"
 	"strconv"
 
@@ -60,6 +61,7 @@ func CreateMihrimahCard(c *fiber.Ctx) error {
 		})
 	}
 
+	sanitizeMihrimahCard(&card)
 	database.DB.Create(&card)
 
 	var cards []models.MihrimahCard
@@ -92,6 +94,7 @@ func UpdateMihrimahCard(c *fiber.Ctx) error {
 		})
 	}
 
+	sanitizeMihrimahCard(&card)
 	database.DB.Model(&card).Updates(card)
 
 	var cards []models.MihrimahCard
@@ -125,3 +128,11 @@ func DeleteMihrimahCard(c *fiber.Ctx) error {
 
 	return c.JSON(cards)
 }
+
+// sanitizeMihrimahCard keeps only Quill formatting in the title and content, both of which
+// are rendered as HTML
+func sanitizeMihrimahCard(card *models.MihrimahCard) {
+	sanitizer := security.NewSanitizer()
+	card.Title = sanitizer.SanitizeRichText(sanitizer.SanitizeString(card.Title, 255))
+	card.Content = sanitizer.SanitizeRichText(card.Content)
+}
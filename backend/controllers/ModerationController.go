@@ -0,0 +1,538 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	ws "backend/websocket"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// reportReasons are the reasons a user can pick when reporting
+var reportReasons = map[string]bool{
+	"spam":       true,
+	"harassment": true,
+	"hate":       true,
+	"sexual":     true,
+	"violence":   true,
+	"copyright":  true,
+	"other":      true,
+}
+
+// moderationActions lists the actions allowed for each target type
+var moderationActions = map[string]map[string]bool{
+	models.ReportTargetComment: {"approve": true, "hide": true, "delete": true, "warn": true},
+	models.ReportTargetPoem:    {"approve": true, "delete": true},
+	models.ReportTargetProfile: {"approve": true, "warn": true},
+	models.ReportTargetReview:  {"approve": true, "hide": true, "delete": true, "warn": true},
+}
+
+// ModerationQueueItem is one reported or held target waiting for a moderator
+type ModerationQueueItem struct {
+	TargetType  string    `json:"target_type"`
+	TargetID    uint      `json:"target_id"`
+	ReportCount int       `json:"report_count"`
+	FlaggedAt   time.Time `json:"flagged_at"`
+
+	// Filled in by attachModerationTargets
+	Reasons       map[string]int     `json:"reasons" gorm:"-"`
+	Comment       *models.Comment    `json:"comment,omitempty" gorm:"-"`
+	Poem          *models.Poem       `json:"poem,omitempty" gorm:"-"`
+	Profile       *models.Admin      `json:"profile,omitempty" gorm:"-"`
+	Review        *models.BookReview `json:"review,omitempty" gorm:"-"`
+	AuthorID      uint               `json:"author_id,omitempty" gorm:"-"`
+	AuthorStrikes int64              `json:"author_strikes" gorm:"-"`
+}
+
+// CreateReport lets a user report a comment, poem, profile or book review
+func CreateReport(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data struct {
+		TargetType string `json:"target_type"`
+		TargetID   uint   `json:"target_id"`
+		Reason     string `json:"reason"`
+		Details    string `json:"details"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Hatalı İstek",
+		})
+	}
+	if !reportReasons[data.Reason] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz şikayet nedeni",
+		})
+	}
+	data.Details = helpers.TruncateRunes(data.Details, 1000)
+
+	// Users can only report what they can see
+	switch data.TargetType {
+	case models.ReportTargetComment:
+		var comment models.Comment
+		if err := visibleCommentsQuery(userID, roleID).First(&comment, data.TargetID).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Yorum bulunamadı",
+			})
+		}
+	case models.ReportTargetPoem:
+		if _, err := findVisiblePoem(data.TargetID, roleID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Şiir bulunamadı",
+			})
+		}
+	case models.ReportTargetProfile:
+		var admin models.Admin
+		if err := database.DB.Select("id").First(&admin, data.TargetID).Error; err != nil || admin.ID == userID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Kullanıcı bulunamadı",
+			})
+		}
+	case models.ReportTargetReview:
+		var review models.BookReview
+		if err := visibleReviewsQuery(userID, roleID).Where("book_reviews.id = ?", data.TargetID).First(&review).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "İnceleme bulunamadı",
+			})
+		}
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz şikayet türü",
+		})
+	}
+
+	var existing int64
+	database.DB.Model(&models.Report{}).
+		Where("reporter_id = ? AND target_type = ? AND target_id = ?", userID, data.TargetType, data.TargetID).
+		Count(&existing)
+	if existing > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"message": "Bu içeriği zaten şikayet ettiniz",
+		})
+	}
+
+	report := models.Report{
+		ReporterID: userID,
+		TargetType: data.TargetType,
+		TargetID:   data.TargetID,
+		Reason:     data.Reason,
+		Details:    strings.TrimSpace(data.Details),
+		Status:     models.ReportStatusOpen,
+	}
+	if err := database.DB.Create(&report).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Şikayet kaydedilemedi",
+		})
+	}
+
+	// Enough open reports take a comment or review out of view until a moderator looks at it
+	var heldModel interface{}
+	switch report.TargetType {
+	case models.ReportTargetComment:
+		heldModel = &models.Comment{}
+	case models.ReportTargetReview:
+		heldModel = &models.BookReview{}
+	}
+	if heldModel != nil {
+		var openReports int64
+		database.DB.Model(&models.Report{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", report.TargetType, report.TargetID, models.ReportStatusOpen).
+			Count(&openReports)
+		if openReports >= int64(helpers.GetEnvInt("MODERATION_AUTO_HOLD_REPORTS", 3)) {
+			database.DB.Model(heldModel).
+				Where("id = ? AND status = ?", report.TargetID, models.CommentStatusVisible).
+				Update("status", models.CommentStatusPending)
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Şikayetiniz alındı",
+		"report":  report,
+	})
+}
+
+// GetModerationQueue lists reported targets and held comments and reviews, most reported first
+func GetModerationQueue(c *fiber.Ctx) error {
+	params := helpers.GetPaginationParams(c)
+	targetType := c.Query("type")
+
+	typeCondition := ""
+	args := map[string]interface{}{
+		"open":    models.ReportStatusOpen,
+		"pending": models.CommentStatusPending,
+		"comment": models.ReportTargetComment,
+		"review":  models.ReportTargetReview,
+		"offset":  params.Offset,
+		"limit":   params.Limit,
+	}
+	if targetType != "" {
+		if _, ok := moderationActions[targetType]; !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Geçersiz içerik türü",
+			})
+		}
+		typeCondition = "WHERE target_type = @type"
+		args["type"] = targetType
+	}
+
+	queue := `WITH queue AS (
+			SELECT target_type, target_id, COUNT(*) AS report_count, MIN(created_at) AS flagged_at
+			FROM reports WHERE status = @open
+			GROUP BY target_type, target_id
+			UNION ALL
+			SELECT CAST(@comment AS varchar(16)), comments.id, 0, COALESCE(comments.created_at, NOW())
+			FROM comments
+			WHERE comments.status = @pending AND comments.is_deleted = false
+			AND NOT EXISTS (
+				SELECT 1 FROM reports
+				WHERE reports.target_type = @comment AND reports.target_id = comments.id AND reports.status = @open
+			)
+			UNION ALL
+			SELECT CAST(@review AS varchar(16)), book_reviews.id, 0, book_reviews.updated_at
+			FROM book_reviews
+			WHERE book_reviews.status = @pending
+			AND NOT EXISTS (
+				SELECT 1 FROM reports
+				WHERE reports.target_type = @review AND reports.target_id = book_reviews.id AND reports.status = @open
+			)
+		)`
+
+	var items []ModerationQueueItem
+	database.DB.Raw(queue+` SELECT target_type, target_id, report_count, flagged_at FROM queue `+typeCondition+`
+		ORDER BY report_count DESC, flagged_at ASC
+		OFFSET @offset LIMIT @limit`, args).Scan(&items)
+
+	var total int64
+	database.DB.Raw(queue+` SELECT COUNT(*) FROM queue `+typeCondition, args).Scan(&total)
+
+	attachModerationTargets(items)
+
+	return c.JSON(helpers.CreatePaginationResponse(items, total, params.Offset, params.Limit))
+}
+
+// attachModerationTargets loads reasons, targets and author strike counts for queue items in batches
+func attachModerationTargets(items []ModerationQueueItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	idsByType := make(map[string][]uint)
+	for _, item := range items {
+		idsByType[item.TargetType] = append(idsByType[item.TargetType], item.TargetID)
+	}
+
+	reasons := make(map[string]map[string]int)
+	for targetType, ids := range idsByType {
+		var rows []struct {
+			TargetID uint
+			Reason   string
+			Count    int
+		}
+		database.DB.Model(&models.Report{}).
+			Select("target_id, reason, COUNT(*) AS count").
+			Where("target_type = ? AND target_id IN ? AND status = ?", targetType, ids, models.ReportStatusOpen).
+			Group("target_id, reason").
+			Scan(&rows)
+		for _, row := range rows {
+			key := fmt.Sprintf("%s:%d", targetType, row.TargetID)
+			if reasons[key] == nil {
+				reasons[key] = make(map[string]int)
+			}
+			reasons[key][row.Reason] = row.Count
+		}
+	}
+
+	adminColumns := func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "email", "role_id", "profile_image")
+	}
+
+	comments := make(map[uint]*models.Comment)
+	if ids := idsByType[models.ReportTargetComment]; len(ids) > 0 {
+		var rows []models.Comment
+		database.DB.Preload("Admin", adminColumns).Where("id IN ?", ids).Find(&rows)
+		for i := range rows {
+			comments[rows[i].ID] = &rows[i]
+		}
+	}
+	poems := make(map[uint]*models.Poem)
+	if ids := idsByType[models.ReportTargetPoem]; len(ids) > 0 {
+		var rows []models.Poem
+		database.DB.Preload("AuthorData").Where("id IN ?", ids).Find(&rows)
+		for i := range rows {
+			poems[rows[i].ID] = &rows[i]
+		}
+	}
+	reviews := make(map[uint]*models.BookReview)
+	if ids := idsByType[models.ReportTargetReview]; len(ids) > 0 {
+		var rows []models.BookReview
+		database.DB.Preload("Admin", adminColumns).Where("id IN ?", ids).Find(&rows)
+		for i := range rows {
+			reviews[rows[i].ID] = &rows[i]
+		}
+	}
+	profiles := make(map[uint]*models.Admin)
+	if ids := idsByType[models.ReportTargetProfile]; len(ids) > 0 {
+		var rows []models.Admin
+		adminColumns(database.DB).Where("id IN ?", ids).Find(&rows)
+		for i := range rows {
+			profiles[rows[i].ID] = &rows[i]
+		}
+	}
+
+	authorIDs := make([]uint, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		item.Reasons = reasons[fmt.Sprintf("%s:%d", item.TargetType, item.TargetID)]
+		switch item.TargetType {
+		case models.ReportTargetComment:
+			item.Comment = comments[item.TargetID]
+			if item.Comment != nil {
+				item.AuthorID = item.Comment.AdminID
+			}
+		case models.ReportTargetPoem:
+			item.Poem = poems[item.TargetID]
+		case models.ReportTargetProfile:
+			item.Profile = profiles[item.TargetID]
+			item.AuthorID = item.TargetID
+		case models.ReportTargetReview:
+			item.Review = reviews[item.TargetID]
+			if item.Review != nil {
+				item.AuthorID = item.Review.AdminID
+			}
+		}
+		if item.AuthorID != 0 {
+			authorIDs = append(authorIDs, item.AuthorID)
+		}
+	}
+
+	strikes := userStrikeCounts(authorIDs)
+	for i := range items {
+		items[i].AuthorStrikes = strikes[items[i].AuthorID]
+	}
+}
+
+// userStrikeCounts returns the number of strikes of each user
+func userStrikeCounts(userIDs []uint) map[uint]int64 {
+	counts := make(map[uint]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts
+	}
+
+	var rows []struct {
+		AdminID uint
+		Count   int64
+	}
+	database.DB.Model(&models.UserStrike{}).
+		Select("admin_id, COUNT(*) AS count").
+		Where("admin_id IN ?", userIDs).
+		Group("admin_id").
+		Scan(&rows)
+	for _, row := range rows {
+		counts[row.AdminID] = row.Count
+	}
+	return counts
+}
+
+// ModerateTarget applies a moderation action to a comment, poem, profile or review and closes its open reports.
+// approve makes a held comment or review visible and dismisses reports; hide and delete take content down;
+// warn records a strike against the author and notifies them.
+func ModerateTarget(c *fiber.Ctx) error {
+	moderatorID := GetUserId(c)
+
+	var data struct {
+		TargetType string `json:"target_type"`
+		TargetID   uint   `json:"target_id"`
+		Action     string `json:"action"`
+		Note       string `json:"note"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Hatalı İstek",
+		})
+	}
+	if !moderationActions[data.TargetType][data.Action] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": fmt.Sprintf("%q işlemi %q için kullanılamaz", data.Action, data.TargetType),
+		})
+	}
+
+	var warnedUserID uint
+	var approvedComment *models.Comment
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		switch data.TargetType {
+		case models.ReportTargetComment:
+			var comment models.Comment
+			if err := tx.First(&comment, data.TargetID).Error; err != nil {
+				return err
+			}
+			switch data.Action {
+			case "approve":
+				wasHeld := comment.Status != models.CommentStatusVisible
+				if err := tx.Model(&comment).Update("status", models.CommentStatusVisible).Error; err != nil {
+					return err
+				}
+				if wasHeld {
+					approvedComment = &comment
+				}
+			case "hide":
+				if err := tx.Model(&comment).Update("status", models.CommentStatusHidden).Error; err != nil {
+					return err
+				}
+			case "delete":
+				if err := tx.Model(&comment).Update("is_deleted", true).Error; err != nil {
+					return err
+				}
+			case "warn":
+				warnedUserID = comment.AdminID
+			}
+		case models.ReportTargetPoem:
+			var poem models.Poem
+			if err := tx.First(&poem, data.TargetID).Error; err != nil {
+				return err
+			}
+			if data.Action == "delete" {
+				if err := tx.Model(&poem).Update("is_deleted", true).Error; err != nil {
+					return err
+				}
+			}
+		case models.ReportTargetProfile:
+			var admin models.Admin
+			if err := tx.Select("id").First(&admin, data.TargetID).Error; err != nil {
+				return err
+			}
+			if data.Action == "warn" {
+				warnedUserID = admin.ID
+			}
+		case models.ReportTargetReview:
+			var review models.BookReview
+			if err := tx.First(&review, data.TargetID).Error; err != nil {
+				return err
+			}
+			switch data.Action {
+			case "approve":
+				if err := tx.Model(&review).Update("status", models.CommentStatusVisible).Error; err != nil {
+					return err
+				}
+			case "hide":
+				if err := tx.Model(&review).Update("status", models.CommentStatusHidden).Error; err != nil {
+					return err
+				}
+			case "delete":
+				// The rating goes with the review
+				if err := lockBook(tx, review.BookID); err != nil {
+					return err
+				}
+				if err := tx.Delete(&review).Error; err != nil {
+					return err
+				}
+				if err := recalculateBookRatings(tx, review.BookID); err != nil {
+					return err
+				}
+			case "warn":
+				warnedUserID = review.AdminID
+			}
+		}
+
+		if warnedUserID != 0 {
+			strike := models.UserStrike{
+				AdminID:    warnedUserID,
+				IssuedByID: moderatorID,
+				TargetType: data.TargetType,
+				TargetID:   data.TargetID,
+				Reason:     data.Note,
+			}
+			if err := tx.Create(&strike).Error; err != nil {
+				return err
+			}
+		}
+
+		status := models.ReportStatusResolved
+		if data.Action == "approve" {
+			status = models.ReportStatusDismissed
+		}
+		now := time.Now()
+		return tx.Model(&models.Report{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", data.TargetType, data.TargetID, models.ReportStatusOpen).
+			Updates(map[string]interface{}{
+				"status":         status,
+				"resolved_by_id": moderatorID,
+				"resolved_at":    now,
+				"resolution":     data.Action,
+			}).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "İçerik bulunamadı",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "İşlem uygulanamadı",
+		})
+	}
+
+	// Replies and mentions in a held comment are announced once it is published
+	if approvedComment != nil {
+		var parent models.Comment
+		if approvedComment.ParentID != nil &&
+			database.DB.Where("id = ? AND is_deleted = ?", *approvedComment.ParentID, false).First(&parent).Error == nil {
+			var replier models.Admin
+			database.DB.Select("id", "username").First(&replier, approvedComment.AdminID)
+			notifyCommentReply(parent, *approvedComment, replier)
+		}
+		var mentions []models.CommentMention
+		database.DB.Where("comment_id = ?", approvedComment.ID).Find(&mentions)
+		notifyCommentMentions(*approvedComment, mentions)
+	}
+
+	response := fiber.Map{
+		"message":     "İşlem uygulandı",
+		"target_type": data.TargetType,
+		"target_id":   data.TargetID,
+		"action":      data.Action,
+	}
+	if warnedUserID != 0 {
+		strikes := userStrikeCounts([]uint{warnedUserID})[warnedUserID]
+		fmt.Printf("[WebSocket] Sending moderation_warning to user %d, strikes: %d\n", warnedUserID, strikes)
+		ws.GlobalHub.SendToUser(warnedUserID, "moderation_warning", map[string]interface{}{
+			"target_type": data.TargetType,
+			"target_id":   data.TargetID,
+			"reason":      data.Note,
+			"strikes":     strikes,
+		})
+		response["strikes"] = strikes
+	}
+
+	return c.JSON(response)
+}
+
+// GetUserStrikes lists the strikes of a user, newest first
+func GetUserStrikes(c *fiber.Ctx) error {
+	userID, err := c.ParamsInt("user_id")
+	if err != nil || userID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz kullanıcı",
+		})
+	}
+
+	strikes := []models.UserStrike{}
+	database.DB.Where("admin_id = ?", userID).Order("id DESC").Find(&strikes)
+
+	return c.JSON(fiber.Map{
+		"user_id": userID,
+		"count":   len(strikes),
+		"strikes": strikes,
+	})
+}
@@ -0,0 +1,264 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// noteTimeLayout is how note timestamps are written in Markdown and HTML exports
+const noteTimeLayout = "02.01.2006 15:04"
+
+// ExportedNote is one of the user's comments on a book
+type ExportedNote struct {
+	ID        uint       `json:"id"`
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	Page      *int       `json:"page"`
+	ParentID  *uint      `json:"parent_id"` // Set when the note is a reply in a discussion
+	Status    string     `json:"status"`
+	CreatedAt *time.Time `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+}
+
+// ExportedBookNotes groups a user's notes on one book, ordered by page
+type ExportedBookNotes struct {
+	BookID    uint           `json:"book_id"`
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	Author    string         `json:"author"`
+	ISBN13    string         `json:"isbn13,omitempty"`
+	Publisher string         `json:"publisher,omitempty"`
+	Notes     []ExportedNote `json:"notes"`
+}
+
+// NotesExport is the JSON export of a user's reading notes
+type NotesExport struct {
+	Username   string              `json:"username"`
+	ExportedAt time.Time           `json:"exported_at"`
+	NoteCount  int                 `json:"note_count"`
+	Books      []ExportedBookNotes `json:"books"`
+}
+
+// loadNotesExport collects the user's notes on books, optionally for one book. Books are
+// ordered by name and notes by page; notes without a page come last in writing order.
+func loadNotesExport(userID uint, bookID uint) NotesExport {
+	var user models.Admin
+	database.DB.Select("id", "username").First(&user, userID)
+
+	query := database.DB.
+		Joins("JOIN books ON books.id = comments.book_id").
+		Preload("Book.AuthorData").
+		Where("comments.admin_id = ? AND comments.book_id IS NOT NULL AND comments.is_deleted = ?", userID, false)
+	if bookID != 0 {
+		query = query.Where("comments.book_id = ?", bookID)
+	}
+	var comments []models.Comment
+	query.Order("books.name ASC, books.id ASC, comments.page ASC NULLS LAST, comments.created_at ASC NULLS FIRST, comments.id ASC").
+		Find(&comments)
+
+	export := NotesExport{
+		Username:   user.Username,
+		ExportedAt: helpers.AppNow(),
+		NoteCount:  len(comments),
+		Books:      []ExportedBookNotes{},
+	}
+	for _, comment := range comments {
+		if comment.Book == nil {
+			continue
+		}
+		if len(export.Books) == 0 || export.Books[len(export.Books)-1].BookID != comment.Book.ID {
+			book := comment.Book
+			author := book.Author
+			if book.AuthorData != nil {
+				author = book.AuthorData.Name
+			}
+			export.Books = append(export.Books, ExportedBookNotes{
+				BookID:    book.ID,
+				Name:      book.Name,
+				Slug:      book.Slug,
+				Author:    author,
+				ISBN13:    book.ISBN13,
+				Publisher: book.Publisher,
+				Notes:     []ExportedNote{},
+			})
+		}
+		group := &export.Books[len(export.Books)-1]
+		group.Notes = append(group.Notes, ExportedNote{
+			ID:        comment.ID,
+			Title:     comment.Title,
+			Content:   comment.Content,
+			Page:      comment.Page,
+			ParentID:  comment.ParentID,
+			Status:    comment.Status,
+			CreatedAt: comment.CreatedAt,
+			EditedAt:  comment.EditedAt,
+		})
+	}
+	return export
+}
+
+// noteHeading is the "s. 42 · Title" line of a note
+func noteHeading(note ExportedNote) string {
+	parts := []string{}
+	if note.Page != nil {
+		parts = append(parts, "s. "+strconv.Itoa(*note.Page))
+	}
+	if note.Title != "" {
+		parts = append(parts, note.Title)
+	}
+	if len(parts) == 0 {
+		return "Not"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// noteTimestamp describes when a note was written and last edited
+func noteTimestamp(note ExportedNote) string {
+	if note.CreatedAt == nil {
+		return ""
+	}
+	stamp := note.CreatedAt.In(helpers.AppLocation()).Format(noteTimeLayout)
+	if note.EditedAt != nil {
+		stamp += " (düzenlendi " + note.EditedAt.In(helpers.AppLocation()).Format(noteTimeLayout) + ")"
+	}
+	return stamp
+}
+
+// renderNotesMarkdown writes the export as Markdown, one section per book
+func renderNotesMarkdown(export NotesExport) string {
+	var out strings.Builder
+	out.WriteString("# Okuma Notları\n\n")
+	fmt.Fprintf(&out, "%s · %d not · %s\n", export.Username, export.NoteCount,
+		export.ExportedAt.Format(noteTimeLayout))
+
+	for _, book := range export.Books {
+		fmt.Fprintf(&out, "\n## %s\n\n", book.Name)
+		if book.Author != "" {
+			fmt.Fprintf(&out, "*%s*\n\n", book.Author)
+		}
+		for _, note := range book.Notes {
+			fmt.Fprintf(&out, "### %s\n\n", noteHeading(note))
+			for _, line := range strings.Split(strings.TrimSpace(note.Content), "\n") {
+				out.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+			}
+			if stamp := noteTimestamp(note); stamp != "" {
+				fmt.Fprintf(&out, "\n<sub>%s</sub>\n", stamp)
+			}
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+// renderNotesHTML writes the export as a standalone, printable XHTML page. It is well-formed
+// XML so it can also be dropped into an EPUB as a chapter.
+func renderNotesHTML(export NotesExport) string {
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" lang="tr" xml:lang="tr">
+<head>
+<meta charset="UTF-8" />
+<title>Okuma Notları</title>
+<style>
+body { font-family: Georgia, serif; max-width: 42em; margin: 2em auto; line-height: 1.5; color: #222; }
+h1 { margin-bottom: 0; }
+.meta, .author, .time { color: #666; }
+section.book { margin-top: 2.5em; }
+.note { margin: 1.2em 0; }
+.note h3 { font-size: 1em; margin: 0 0 0.3em; }
+blockquote { margin: 0; padding-left: 1em; border-left: 3px solid #ccc; white-space: pre-wrap; }
+.time { font-size: 0.85em; }
+@media print { section.book { page-break-before: always; } section.book:first-of-type { page-break-before: auto; } }
+</style>
+</head>
+<body>
+`)
+	fmt.Fprintf(&out, "<h1>Okuma Notları</h1>\n<p class=\"meta\">%s · %d not · %s</p>\n",
+		html.EscapeString(export.Username), export.NoteCount, export.ExportedAt.Format(noteTimeLayout))
+
+	for _, book := range export.Books {
+		fmt.Fprintf(&out, "<section class=\"book\" id=\"book-%d\">\n<h2>%s</h2>\n", book.BookID, html.EscapeString(book.Name))
+		if book.Author != "" {
+			fmt.Fprintf(&out, "<p class=\"author\">%s</p>\n", html.EscapeString(book.Author))
+		}
+		for _, note := range book.Notes {
+			fmt.Fprintf(&out, "<div class=\"note\" id=\"note-%d\">\n<h3>%s</h3>\n<blockquote>%s</blockquote>\n",
+				note.ID, html.EscapeString(noteHeading(note)), html.EscapeString(strings.TrimSpace(note.Content)))
+			if stamp := noteTimestamp(note); stamp != "" {
+				fmt.Fprintf(&out, "<p class=\"time\">%s</p>\n", html.EscapeString(stamp))
+			}
+			out.WriteString("</div>\n")
+		}
+		out.WriteString("</section>\n")
+	}
+	out.WriteString("</body>\n</html>\n")
+	return out.String()
+}
+
+// ExportNotes downloads the session user's notes (their comments on books) grouped by book
+// and ordered by page. ?format=markdown|json|html, ?book_id= limits the export to one book.
+func ExportNotes(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	if userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	bookID, _ := strconv.Atoi(c.Query("book_id"))
+	export := loadNotesExport(userID, uint(bookID))
+	if bookID != 0 && len(export.Books) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Bu kitap için not bulunamadı",
+		})
+	}
+
+	name := "notlar"
+	if bookID != 0 {
+		name += "-" + export.Books[0].Slug
+	}
+	name += "-" + export.ExportedAt.Format("20060102")
+
+	var body []byte
+	format := c.Query("format", "markdown")
+	switch format {
+	case "markdown", "md":
+		c.Set(fiber.HeaderContentType, "text/markdown; charset=utf-8")
+		name += ".md"
+		body = []byte(renderNotesMarkdown(export))
+	case "html":
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		name += ".html"
+		body = []byte(renderNotesHTML(export))
+	case "json":
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "Notlar dışa aktarılamadı",
+			})
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		name += ".json"
+		body = data
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz biçim (markdown, json veya html)",
+		})
+	}
+
+	fmt.Printf("[ExportNotes] userID=%d, bookID=%d, format=%s, books=%d, notes=%d\n",
+		userID, bookID, format, len(export.Books), export.NoteCount)
+
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+name+`"`)
+	return c.Send(body)
+}
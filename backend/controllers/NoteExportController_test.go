@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleNotesExport() NotesExport {
+	page := 42
+	created := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	return NotesExport{
+		Username:   "okur",
+		ExportedAt: created,
+		NoteCount:  2,
+		Books: []ExportedBookNotes{{
+			BookID: 7,
+			Name:   "Tutunamayanlar & <Diğerleri>",
+			Author: "Oğuz Atay",
+			Notes: []ExportedNote{
+				{ID: 1, Title: "Selim", Content: "İlk satır\nikinci <b>satır</b>", Page: &page, CreatedAt: &created},
+				{ID: 2, Content: "Sayfasız not"},
+			},
+		}},
+	}
+}
+
+func TestNoteHeading(t *testing.T) {
+	page := 12
+	tests := []struct {
+		note ExportedNote
+		want string
+	}{
+		{ExportedNote{Page: &page, Title: "Başlık"}, "s. 12 · Başlık"},
+		{ExportedNote{Page: &page}, "s. 12"},
+		{ExportedNote{Title: "Başlık"}, "Başlık"},
+		{ExportedNote{}, "Not"},
+	}
+	for _, tt := range tests {
+		if got := noteHeading(tt.note); got != tt.want {
+			t.Errorf("noteHeading(%+v) = %q, want %q", tt.note, got, tt.want)
+		}
+	}
+}
+
+func TestRenderNotesMarkdownQuotesEveryLine(t *testing.T) {
+	out := renderNotesMarkdown(sampleNotesExport())
+	for _, want := range []string{
+		"## Tutunamayanlar & <Diğerleri>\n",
+		"### s. 42 · Selim\n\n> İlk satır\n> ikinci <b>satır</b>\n",
+		"### Not\n\n> Sayfasız not\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown export is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderNotesHTMLIsWellFormed(t *testing.T) {
+	out := renderNotesHTML(sampleNotesExport())
+	if strings.Contains(out, "<b>") || strings.Contains(out, "<Diğerleri>") {
+		t.Fatalf("html export contains unescaped user text:\n%s", out)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(out))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("html export is not well-formed XML: %v", err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// unreadNotificationCount counts the user's unread notifications
+func unreadNotificationCount(userID uint) int64 {
+	var count int64
+	database.DB.Model(&models.Notification{}).Where("admin_id = ? AND read_at IS NULL", userID).Count(&count)
+	return count
+}
+
+// unreadNotificationCounts counts unread notifications for many users with one query per
+// batch of 500; users without unread notifications are missing from the map
+func unreadNotificationCounts(userIDs []uint) map[uint]int64 {
+	counts := make(map[uint]int64, len(userIDs))
+	for start := 0; start < len(userIDs); start += 500 {
+		batch := userIDs[start:min(start+500, len(userIDs))]
+		var rows []struct {
+			AdminID uint
+			Count   int64
+		}
+		database.DB.Model(&models.Notification{}).
+			Select("admin_id, COUNT(*) AS count").
+			Where("admin_id IN ? AND read_at IS NULL", batch).
+			Group("admin_id").
+			Scan(&rows)
+		for _, row := range rows {
+			counts[row.AdminID] = row.Count
+		}
+	}
+	return counts
+}
+
+// GetNotifications returns the session user's notifications, newest first. ?unread=true
+// lists only unread ones.
+func GetNotifications(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	params := helpers.GetPaginationParams(c)
+
+	query := database.DB.Model(&models.Notification{}).Where("admin_id = ?", userID)
+	if c.Query("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	query.Count(&total)
+
+	notifications := []models.Notification{}
+	query.Preload("Author").
+		Order("created_at DESC, id DESC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&notifications)
+
+	return c.JSON(fiber.Map{
+		"notifications": helpers.CreatePaginationResponse(notifications, total, params.Offset, params.Limit),
+		"unread_count":  unreadNotificationCount(userID),
+	})
+}
+
+// MarkNotificationsRead marks the given notifications ("ids") or, without ids, all of the
+// session user's notifications as read
+func MarkNotificationsRead(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+
+	var data struct {
+		IDs []uint `json:"ids"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&data); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Geçersiz istek",
+			})
+		}
+	}
+
+	query := database.DB.Model(&models.Notification{}).Where("admin_id = ? AND read_at IS NULL", userID)
+	if len(data.IDs) > 0 {
+		query = query.Where("id IN ?", data.IDs)
+	}
+	if err := query.Update("read_at", helpers.AppNow()).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Bildirimler güncellenemedi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"unread_count": unreadNotificationCount(userID),
+	})
+}
+
+// DeleteNotification removes one of the session user's notifications
+func DeleteNotification(c *fiber.Ctx) error {
+	id, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+
+	result := database.DB.Where("id = ? AND admin_id = ?", id, userID).Delete(&models.Notification{})
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Bildirim silinemedi",
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Bildirim bulunamadı",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"unread_count": unreadNotificationCount(userID),
+	})
+}
@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"fmt"
+	"html"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Targets of the daily anniversary job (ON_THIS_DAY_TARGET)
+const (
+	onThisDayTargetHomepage = "homepage"
+	onThisDayTargetMihrimah = "mihrimah"
+)
+
+const onThisDayTitle = "Bugün Edebiyatta"
+
+var turkishMonths = [...]string{
+	"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran",
+	"Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık",
+}
+
+// OnThisDayEntry is an anniversary: an author's birth or death, or a book's or poem's first publication
+type OnThisDayEntry struct {
+	Type       string `json:"type"` // birth, death, book or poem
+	ID         uint   `json:"id"`   // Author id for births and deaths, else the book or poem id
+	Title      string `json:"title"`
+	Slug       string `json:"slug"`
+	Date       string `json:"date"`
+	Year       int    `json:"year"`
+	YearsAgo   int    `json:"years_ago"`
+	AuthorName string `json:"author_name,omitempty"`
+	AuthorSlug string `json:"author_slug,omitempty"`
+}
+
+// OnThisDay is the list of anniversaries falling on a day
+type OnThisDay struct {
+	Date    string           `json:"date"`
+	Label   string           `json:"label"` // "19 Ekim"
+	Entries []OnThisDayEntry `json:"entries"`
+}
+
+// anniversaryKeys returns the MM-DD keys celebrated on a day. Anniversaries of 29 February
+// are kept on 28 February in common years.
+func anniversaryKeys(day time.Time) []string {
+	keys := []string{day.Format("01-02")}
+	if day.Month() == time.February && day.Day() == 28 {
+		if time.Date(day.Year(), time.February, 29, 0, 0, 0, 0, day.Location()).Month() != time.February {
+			keys = append(keys, "02-29")
+		}
+	}
+	return keys
+}
+
+// onThisDayLabel formats a day as "19 Ekim"
+func onThisDayLabel(day time.Time) string {
+	return strconv.Itoa(day.Day()) + " " + turkishMonths[day.Month()-1]
+}
+
+// newOnThisDayEntry fills the year fields of an entry from its YYYY-MM-DD date
+func newOnThisDayEntry(entryType string, id uint, title, slug, date string, day time.Time) OnThisDayEntry {
+	year, _ := strconv.Atoi(date[:4])
+	return OnThisDayEntry{
+		Type:     entryType,
+		ID:       id,
+		Title:    title,
+		Slug:     slug,
+		Date:     date,
+		Year:     year,
+		YearsAgo: day.Year() - year,
+	}
+}
+
+// loadOnThisDay collects the anniversaries of a day that the community level can see,
+// oldest first
+func loadOnThisDay(day time.Time, level int) OnThisDay {
+	keys := anniversaryKeys(day)
+	result := OnThisDay{
+		Date:    day.Format(helpers.DateLayout),
+		Label:   onThisDayLabel(day),
+		Entries: []OnThisDayEntry{},
+	}
+
+	var born, died []models.Author
+	database.DB.Where("is_deleted = ? AND SUBSTRING(birth_date FROM 6) IN ?", false, keys).Find(&born)
+	database.DB.Where("is_deleted = ? AND SUBSTRING(death_date FROM 6) IN ?", false, keys).Find(&died)
+	for _, author := range born {
+		result.Entries = append(result.Entries, newOnThisDayEntry("birth", author.ID, author.Name, author.Slug, *author.BirthDate, day))
+	}
+	for _, author := range died {
+		result.Entries = append(result.Entries, newOnThisDayEntry("death", author.ID, author.Name, author.Slug, *author.DeathDate, day))
+	}
+
+	var books []models.Book
+	applyCommunityLevelFilter(database.DB.Preload("AuthorData"), level).
+		Where("is_deleted = ? AND SUBSTRING(first_published_date FROM 6) IN ?", false, keys).
+		Find(&books)
+	for _, book := range books {
+		entry := newOnThisDayEntry("book", book.ID, book.Name, book.Slug, *book.FirstPublishedDate, day)
+		if book.AuthorData != nil {
+			entry.AuthorName, entry.AuthorSlug = book.AuthorData.Name, book.AuthorData.Slug
+		} else {
+			entry.AuthorName = book.Author
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	var poems []models.Poem
+	applyCommunityLevelFilter(database.DB.Preload("AuthorData"), level).
+		Where("is_deleted = ? AND SUBSTRING(first_published_date FROM 6) IN ?", false, keys).
+		Find(&poems)
+	for _, poem := range poems {
+		entry := newOnThisDayEntry("poem", poem.ID, poem.Title, poem.Slug, *poem.FirstPublishedDate, day)
+		if poem.AuthorData != nil {
+			entry.AuthorName, entry.AuthorSlug = poem.AuthorData.Name, poem.AuthorData.Slug
+		} else {
+			entry.AuthorName = poem.Author
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	sort.SliceStable(result.Entries, func(i, j int) bool {
+		if result.Entries[i].Year != result.Entries[j].Year {
+			return result.Entries[i].Year < result.Entries[j].Year
+		}
+		return result.Entries[i].Title < result.Entries[j].Title
+	})
+	return result
+}
+
+// GetOnThisDay returns the literary anniversaries of today in the application timezone, or
+// of ?date=YYYY-MM-DD
+func GetOnThisDay(c *fiber.Ctx) error {
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	day := helpers.AppNow()
+	if date := c.Query("date"); date != "" {
+		if day, err = helpers.ParseAppDate(date); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "date must be in YYYY-MM-DD format",
+			})
+		}
+	}
+
+	return c.JSON(loadOnThisDay(day, communityLevelForRole(roleID)))
+}
+
+// renderOnThisDay writes the anniversaries as Quill paragraphs for a homepage item or card
+func renderOnThisDay(day OnThisDay) string {
+	var out strings.Builder
+	for _, entry := range day.Entries {
+		title := html.EscapeString(entry.Title)
+		var line string
+		switch entry.Type {
+		case "birth":
+			line = title + " doğdu"
+		case "death":
+			line = title + " hayatını kaybetti"
+		case "book":
+			line = "<em>" + title + "</em> yayımlandı"
+		case "poem":
+			line = "\"" + title + "\" şiiri yayımlandı"
+		}
+		if entry.AuthorName != "" {
+			line = html.EscapeString(entry.AuthorName) + ": " + line
+		}
+		if entry.YearsAgo > 0 {
+			line += fmt.Sprintf(" (%d. yıl dönümü)", entry.YearsAgo)
+		}
+		fmt.Fprintf(&out, "<p><strong>%d</strong> · %s</p>", entry.Year, line)
+	}
+	return out.String()
+}
+
+// publishOnThisDay replaces the items created on earlier days with today's anniversaries.
+// Homepage items are created per permission level with the content that level can see;
+// Mihrimah cards are visible to everyone and only list public content.
+func publishOnThisDay(target string, day time.Time) error {
+	date := day.Format(helpers.DateLayout)
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var previous []models.OnThisDayPost
+		tx.Where("date <> ?", date).Find(&previous)
+		for _, post := range previous {
+			var err error
+			if post.Target == onThisDayTargetMihrimah {
+				err = tx.Delete(&models.MihrimahCard{}, post.ItemID).Error
+			} else {
+				err = tx.Delete(&models.Homepage{}, post.ItemID).Error
+			}
+			if err != nil {
+				return err
+			}
+			if err := tx.Delete(&post).Error; err != nil {
+				return err
+			}
+		}
+
+		var published int64
+		tx.Model(&models.OnThisDayPost{}).Where("date = ? AND target = ?", date, target).Count(&published)
+		if published > 0 {
+			return nil
+		}
+
+		if target == onThisDayTargetMihrimah {
+			anniversaries := loadOnThisDay(day, communityLevelPublic)
+			if len(anniversaries.Entries) == 0 {
+				return nil
+			}
+			card := models.MihrimahCard{
+				Title:   onThisDayTitle + " · " + anniversaries.Label,
+				Content: renderOnThisDay(anniversaries),
+			}
+			sanitizeMihrimahCard(&card)
+			if err := tx.Create(&card).Error; err != nil {
+				return err
+			}
+			return tx.Create(&models.OnThisDayPost{Date: date, Target: target, ItemID: card.ID}).Error
+		}
+
+		for _, permission := range []int{1, 2, 3} {
+			anniversaries := loadOnThisDay(day, communityLevelForRole(uint(permission)))
+			if len(anniversaries.Entries) == 0 {
+				continue
+			}
+			item := models.Homepage{
+				Title:      onThisDayTitle,
+				Subtitle:   anniversaries.Label,
+				Content:    renderOnThisDay(anniversaries),
+				Permission: permission,
+			}
+			sanitizeHomepage(&item)
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&models.OnThisDayPost{Date: date, Target: target, ItemID: item.ID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// StartOnThisDayScheduler publishes the day's anniversaries shortly after midnight when
+// ON_THIS_DAY_TARGET is homepage or mihrimah. It does nothing when the variable is unset.
+func StartOnThisDayScheduler() {
+	target := os.Getenv("ON_THIS_DAY_TARGET")
+	if target == "" {
+		return
+	}
+	if target != onThisDayTargetHomepage && target != onThisDayTargetMihrimah {
+		fmt.Printf("[OnThisDay] unknown ON_THIS_DAY_TARGET %q, job disabled\n", target)
+		return
+	}
+	helpers.RunDaily("on-this-day", 0, 5, func() {
+		day := helpers.AppNow()
+		if err := publishOnThisDay(target, day); err != nil {
+			fmt.Printf("[OnThisDay] date=%s target=%s error=%v\n", day.Format(helpers.DateLayout), target, err)
+		}
+	})
+}
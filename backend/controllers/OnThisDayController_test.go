@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnniversaryKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		day  time.Time
+		want []string
+	}{
+		{"ordinary day", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), []string{"10-19"}},
+		// Works born on Feb 29 are remembered on Feb 28 in common years
+		{"Feb 28 in a common year", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), []string{"02-28", "02-29"}},
+		{"Feb 28 in a leap year", time.Date(2028, 2, 28, 0, 0, 0, 0, time.UTC), []string{"02-28"}},
+		{"Feb 29", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), []string{"02-29"}},
+		{"Mar 1 in a common year", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), []string{"03-01"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := anniversaryKeys(tt.day); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("anniversaryKeys(%s) = %v, want %v", tt.day.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnThisDayLabel(t *testing.T) {
+	tests := []struct {
+		day  time.Time
+		want string
+	}{
+		{time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), "1 Ocak"},
+		{time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), "29 Şubat"},
+		{time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), "19 Ekim"},
+		{time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), "31 Aralık"},
+	}
+	for _, tt := range tests {
+		if got := onThisDayLabel(tt.day); got != tt.want {
+			t.Errorf("onThisDayLabel(%s) = %q, want %q", tt.day.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
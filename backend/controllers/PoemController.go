@@ -40,10 +40,36 @@ import (
 func applyCommunityFilter(db *gorm.DB, roleID uint) *gorm.DB {
 	// role_id 1 and 2 can see all poems (community 1 and 2)
 	// role_id 3 can only see public poems (community 2)
-	if roleID == 1 || roleID == 2 {
-		return db // No filter, can see all
+	return applyCommunityLevelFilter(db, communityLevelForRole(roleID))
+}
+
+// resolveTags maps the tags sent with a poem to stored tags, matching by id or by the slug
+// of the name and creating unknown names
+func resolveTags(tx *gorm.DB, input []models.Tag) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	seen := map[uint]bool{}
+	for _, item := range input {
+		var tag models.Tag
+		if item.ID != 0 {
+			if err := tx.First(&tag, item.ID).Error; err != nil {
+				return nil, &security.ValidationError{Field: "tags", Message: fmt.Sprintf("tag %d not found", item.ID)}
+			}
+		} else {
+			name := helpers.TruncateRunes(security.NewSanitizer().SanitizeString(item.Name, 0), 64)
+			slug := helpers.Slugify(name)
+			if slug == "" {
+				continue
+			}
+			if err := tx.Where(models.Tag{Slug: slug}).Attrs(models.Tag{Name: name}).FirstOrCreate(&tag).Error; err != nil {
+				return nil, err
+			}
+		}
+		if !seen[tag.ID] {
+			seen[tag.ID] = true
+			tags = append(tags, tag)
+		}
 	}
-	return db.Where("community = ?", 2) // Only public poems
+	return tags, nil
 }
 
 func replaceChars(s string, replacements map[rune]rune) string {
@@ -91,6 +117,15 @@ func CreatePoem(c *fiber.Ctx) error {
 		})
 	}
 
+	// Validate first publication date if provided
+	firstPublished, err := validator.ValidateDate("first_published_date", poem.FirstPublishedDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	poem.FirstPublishedDate = firstPublished
+
 	// Validate author_id if provided
 	if poem.AuthorID != nil {
 		if err := validator.ValidateID("author_id", *poem.AuthorID); err != nil {
@@ -100,12 +135,17 @@ func CreatePoem(c *fiber.Ctx) error {
 		}
 	}
 
-	// Sanitize inputs to prevent XSS
+	// Sanitize inputs to prevent XSS; content keeps only Quill formatting
 	poem.Title = sanitizer.SanitizeString(poem.Title, 255)
-	poem.Content = sanitizer.SanitizeString(poem.Content, 50000)
+	poem.Content = sanitizer.SanitizeRichText(poem.Content)
+	if sanitizer.SanitizePlainText(poem.Content) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "content is required",
+		})
+	}
 
 	// Check for dangerous content
-	if sanitizer.ContainsDangerousContent(poem.Title) || sanitizer.ContainsDangerousContent(poem.Content) {
+	if sanitizer.ContainsDangerousContent(poem.Title) {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Content contains potentially dangerous elements",
 		})
@@ -135,11 +175,32 @@ func CreatePoem(c *fiber.Ctx) error {
 	poem.CreatedAtParse = time.Now().String()
 	poem.Slug = strings.ToLower(slug)
 
-	if err := database.DB.Create(&poem).Error; err != nil {
+	contributors := poem.Contributors
+	poem.Contributors = nil
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		tags, err := resolveTags(tx, poem.Tags)
+		if err != nil {
+			return err
+		}
+		poem.Tags = tags
+		if err := tx.Create(&poem).Error; err != nil {
+			return err
+		}
+		return replaceContributors(tx, "poem_id", poem.ID, poem.AuthorID, contributors)
+	})
+	if err != nil {
+		var validationErr *security.ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": validationErr.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to create poem",
 		})
 	}
+	indexPoemContent(poem.ID)
+	go notifyAuthorFollowers(poem.AuthorID, poem.Community, models.NotificationAuthorPoem, poem.ID, poem.Title, poem.Slug, GetUserId(c))
 
 	roleID, err := helpers.GetUserRole(c)
 	if err != nil {
@@ -153,6 +214,7 @@ func DeletePoem(c *fiber.Ctx) error {
 	database.DB.Table("poems").Where("id", id).Find(&poem)
 	poem.IsDeleted = true
 	database.DB.Save(&poem)
+	indexPoemContent(poem.ID)
 
 	roleID, err := helpers.GetUserRole(c)
 	if err != nil {
@@ -204,7 +266,12 @@ func UpdatePoem(c *fiber.Ctx) error {
 				"error": err.Error(),
 			})
 		}
-		updateData.Content = sanitizer.SanitizeString(updateData.Content, 50000)
+		updateData.Content = sanitizer.SanitizeRichText(updateData.Content)
+		if sanitizer.SanitizePlainText(updateData.Content) == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "content is required",
+			})
+		}
 	}
 
 	// Validate community if provided
@@ -216,18 +283,63 @@ func UpdatePoem(c *fiber.Ctx) error {
 		}
 	}
 
+	// Validate first publication date if provided
+	firstPublished, err := validator.ValidateDate("first_published_date", updateData.FirstPublishedDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	updateData.FirstPublishedDate = firstPublished
+
 	// Check for dangerous content
-	if sanitizer.ContainsDangerousContent(updateData.Title) || sanitizer.ContainsDangerousContent(updateData.Content) {
+	if sanitizer.ContainsDangerousContent(updateData.Title) {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Content contains potentially dangerous elements",
 		})
 	}
 
-	if err := database.DB.Model(&poem).Updates(updateData).Error; err != nil {
+	// Tags and contributors are replaced only when the request sends them
+	tagInput, contributorInput := updateData.Tags, updateData.Contributors
+	updateData.Tags, updateData.Contributors = nil, nil
+	primary := poem.AuthorID
+	if updateData.AuthorID != nil {
+		primary = updateData.AuthorID
+	}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&poem).Updates(updateData).Error; err != nil {
+			return err
+		}
+		if contributorInput != nil {
+			if err := replaceContributors(tx, "poem_id", poem.ID, primary, contributorInput); err != nil {
+				return err
+			}
+		} else if updateData.AuthorID != nil {
+			if err := syncPrimaryContributor(tx, "poem_id", poem.ID, primary); err != nil {
+				return err
+			}
+		}
+		if tagInput == nil {
+			return nil
+		}
+		tags, err := resolveTags(tx, tagInput)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&poem).Association("Tags").Replace(tags)
+	})
+	if err != nil {
+		var validationErr *security.ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": validationErr.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update poem",
 		})
 	}
+	indexPoemContent(poem.ID)
 
 	roleID, err := helpers.GetUserRole(c)
 	if err != nil {
@@ -249,12 +361,14 @@ func GetPoem(c *fiber.Ctx) error {
 	// Apply community filter when fetching the main poem
 	query := database.DB.Table("poems").Where("slug", slug)
 	query = applyCommunityFilter(query, roleID)
-	result := query.Preload("AuthorData").First(&poem)
+	result := preloadContributors(query.Preload("AuthorData").Preload("Tags")).First(&poem)
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return c.SendString("poem not found")
 	}
 
+	recordPoemView(c, poem.ID)
+
 	// Apply community filter to random poems as well
 	var randomPoem []models.Poem
 	randomQuery := database.DB.
@@ -286,7 +400,7 @@ func GetPoemById(c *fiber.Ctx) error {
 
 	query := database.DB.Table("poems").Where("id", id)
 	query = applyCommunityFilter(query, roleID)
-	result := query.Preload("AuthorData").First(&poem)
+	result := preloadContributors(query.Preload("AuthorData").Preload("Tags")).First(&poem)
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return c.SendString("poem not found")
@@ -298,7 +412,9 @@ func GetAllPoems(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(getPoems(roleID))
+	poems := getPoems(roleID)
+	attachPoemCommentCounts(poems, GetUserId(c), roleID)
+	return c.JSON(poems)
 }
 func getPoems(roleID uint) []models.Poem {
 	var poems []models.Poem
@@ -334,6 +450,7 @@ func GetLatestPoems(c *fiber.Ctx) error {
 
 	query = applyCommunityFilter(query, roleID)
 	query.Scan(&poems)
+	attachPoemListData(poems, GetUserId(c), roleID)
 
 	return c.JSON(poems)
 }
@@ -357,10 +474,13 @@ func GetPoemsPaginated(c *fiber.Ctx) error {
 		Limit(limit)
 
 	query = applyCommunityFilter(query, roleID)
+	query = applyPeriodFilter(query, c, "poems.author_id")
 	query.Scan(&poems)
+	attachPoemListData(poems, GetUserId(c), roleID)
 
 	countQuery := database.DB.Model(&models.Poem{}).Where("is_deleted", false)
 	countQuery = applyCommunityFilter(countQuery, roleID)
+	countQuery = applyPeriodFilter(countQuery, c, "author_id")
 	countQuery.Count(&total)
 
 	return c.JSON(fiber.Map{
@@ -421,6 +541,15 @@ func GetSearchPoems(c *fiber.Ctx) error {
 	// ILIKE is PostgreSQL's case-insensitive LIKE
 	searchPattern := "%" + sanitizedSearch + "%"
 
+	// Optional literary period filter
+	periodClause := ""
+	args := []interface{}{searchPattern, searchPattern, searchPattern}
+	if period := c.Query("period"); period != "" {
+		periodClause = " AND poems.author_id IN (" + periodAuthorsSQL + ")"
+		args = append(args, period)
+	}
+	args = append(args, offset, limit)
+
 	// Apply community filter in raw SQL query with like count
 	var rawQuery string
 	if roleID == 1 || roleID == 2 {
@@ -428,28 +557,31 @@ func GetSearchPoems(c *fiber.Ctx) error {
 		rawQuery = `SELECT poems.*, COUNT(admin_liked_poems.poem_id) as like_count
 					FROM poems
 					LEFT JOIN admin_liked_poems ON poems.id = admin_liked_poems.poem_id
-					WHERE (title ILIKE ? OR content ILIKE ?) AND is_deleted = false
+					WHERE (title ILIKE ? OR content ILIKE ? OR poems.id IN (` + poemContributorSearchSQL + `)) AND is_deleted = false` + periodClause + `
 					GROUP BY poems.id
 					ORDER BY poems.created_at_parse DESC
 					OFFSET ? LIMIT ?`
-		database.DB.Raw(rawQuery, searchPattern, searchPattern, offset, limit).Scan(&poems)
+		database.DB.Raw(rawQuery, args...).Scan(&poems)
 	} else {
 		// Guest (role_id 3): can only see public poems (community = 2)
 		rawQuery = `SELECT poems.*, COUNT(admin_liked_poems.poem_id) as like_count
 					FROM poems
 					LEFT JOIN admin_liked_poems ON poems.id = admin_liked_poems.poem_id
-					WHERE (title ILIKE ? OR content ILIKE ?) AND is_deleted = false AND community = 2
+					WHERE (title ILIKE ? OR content ILIKE ? OR poems.id IN (` + poemContributorSearchSQL + `)) AND is_deleted = false AND community = 2` + periodClause + `
 					GROUP BY poems.id
 					ORDER BY poems.created_at_parse DESC
 					OFFSET ? LIMIT ?`
-		database.DB.Raw(rawQuery, searchPattern, searchPattern, offset, limit).Scan(&poems)
+		database.DB.Raw(rawQuery, args...).Scan(&poems)
 	}
 
+	attachPoemListData(poems, GetUserId(c), roleID)
+
 	// Count query with community filter
 	countQuery := database.DB.Model(&models.Poem{}).
-		Where("title ILIKE ? OR content ILIKE ?", searchPattern, searchPattern).
+		Where("title ILIKE ? OR content ILIKE ? OR id IN ("+poemContributorSearchSQL+")", searchPattern, searchPattern, searchPattern).
 		Where("is_deleted", false)
 	countQuery = applyCommunityFilter(countQuery, roleID)
+	countQuery = applyPeriodFilter(countQuery, c, "author_id")
 	countQuery.Count(&total)
 
 	return c.JSON(fiber.Map{
@@ -475,6 +607,55 @@ type PoemWithLikes struct {
 	CreatedAtParse string `json:"created_at_parse"`
 	Community      int    `json:"community"`
 	LikeCount      int    `json:"like_count"`
+	CommentCount   int    `json:"comment_count"`
+
+	// Filled in by attachPoemListData after the scan
+	AuthorData *models.Author `json:"author_data,omitempty" gorm:"-"`
+}
+
+// attachPoemListData fills in author data and visible comment counts on a poem listing
+func attachPoemListData(poems []PoemWithLikes, userID uint, roleID uint) {
+	if len(poems) == 0 {
+		return
+	}
+
+	poemIDs := make([]uint, 0, len(poems))
+	authorIDs := make([]uint, 0, len(poems))
+	for _, poem := range poems {
+		poemIDs = append(poemIDs, poem.ID)
+		if poem.AuthorID != nil {
+			authorIDs = append(authorIDs, *poem.AuthorID)
+		}
+	}
+
+	authors := make(map[uint]*models.Author, len(authorIDs))
+	if len(authorIDs) > 0 {
+		var rows []models.Author
+		database.DB.Where("id IN ?", authorIDs).Find(&rows)
+		for i := range rows {
+			authors[rows[i].ID] = &rows[i]
+		}
+	}
+
+	commentCounts := visiblePoemCommentCounts(poemIDs, userID, roleID)
+	for i := range poems {
+		if poems[i].AuthorID != nil {
+			poems[i].AuthorData = authors[*poems[i].AuthorID]
+		}
+		poems[i].CommentCount = commentCounts[poems[i].ID]
+	}
+}
+
+// attachPoemCommentCounts fills in visible comment counts on poems loaded through the model
+func attachPoemCommentCounts(poems []models.Poem, userID uint, roleID uint) {
+	poemIDs := make([]uint, 0, len(poems))
+	for _, poem := range poems {
+		poemIDs = append(poemIDs, poem.ID)
+	}
+	commentCounts := visiblePoemCommentCounts(poemIDs, userID, roleID)
+	for i := range poems {
+		poems[i].CommentCount = commentCounts[poems[i].ID]
+	}
 }
 
 // GetPopularPoems - Get poems ordered by like count
@@ -499,11 +680,14 @@ func GetPopularPoems(c *fiber.Ctx) error {
 		Order("like_count DESC")
 
 	query = applyCommunityFilter(query, roleID)
+	query = applyPeriodFilter(query, c, "poems.author_id")
 	query.Offset(offset).Limit(limit).Scan(&poems)
+	attachPoemListData(poems, GetUserId(c), roleID)
 
 	// Count query
 	countQuery := database.DB.Model(&models.Poem{}).Where("is_deleted", false)
 	countQuery = applyCommunityFilter(countQuery, roleID)
+	countQuery = applyPeriodFilter(countQuery, c, "author_id")
 	countQuery.Count(&total)
 
 	return c.JSON(fiber.Map{
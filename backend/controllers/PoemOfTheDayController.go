@@ -0,0 +1,311 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Community levels: the poems and books a role can see, also used to keep daily picks apart
+const (
+	communityLevelMembers = 1 // role_id 1,2 - all poems and books
+	communityLevelPublic  = 2 // role_id 3 - public (community 2) only
+)
+
+var poemOfTheDayLevels = []int{communityLevelMembers, communityLevelPublic}
+
+// communityLevelForRole maps a role to the pool it is allowed to see
+func communityLevelForRole(roleID uint) int {
+	if roleID == 1 || roleID == 2 {
+		return communityLevelMembers
+	}
+	return communityLevelPublic
+}
+
+// applyCommunityLevelFilter restricts poems or books to the pool of the given community level
+func applyCommunityLevelFilter(db *gorm.DB, level int) *gorm.DB {
+	if level == communityLevelMembers {
+		return db
+	}
+	return db.Where("community = ?", 2)
+}
+
+// poemOfTheDayRepeatWindow is the number of days a picked poem is excluded from new picks
+func poemOfTheDayRepeatWindow() int {
+	return helpers.GetEnvInt("POEM_OF_THE_DAY_REPEAT_WINDOW_DAYS", 30)
+}
+
+// StartPoemOfTheDayScheduler makes sure every community level has a pick shortly after midnight
+func StartPoemOfTheDayScheduler() {
+	helpers.RunDaily("poem-of-the-day", 0, 1, func() {
+		today := helpers.AppToday()
+		for _, level := range poemOfTheDayLevels {
+			if _, err := getOrPickPoemOfTheDay(today, level); err != nil {
+				fmt.Printf("[PoemOfTheDay] date=%s level=%d error=%v\n", today, level, err)
+			}
+		}
+	})
+}
+
+// getOrPickPoemOfTheDay returns the stored pick for date/level, picking one if missing
+func getOrPickPoemOfTheDay(date string, level int) (*models.PoemOfTheDay, error) {
+	var pick models.PoemOfTheDay
+	err := database.DB.
+		Where("date = ? AND community_level = ?", date, level).
+		Preload("Poem.AuthorData").
+		First(&pick).Error
+	if err == nil {
+		// A pick whose poem was deleted or hidden from this pool is replaced
+		if !pick.Poem.IsDeleted && (level == communityLevelMembers || pick.Poem.Community == 2) {
+			return &pick, nil
+		}
+		database.DB.Delete(&pick)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	poemID, err := choosePoemOfTheDay(date, level)
+	if err != nil {
+		return nil, err
+	}
+
+	pick = models.PoemOfTheDay{
+		Date:           date,
+		CommunityLevel: level,
+		PoemID:         poemID,
+	}
+	// Concurrent requests may pick at the same time; the unique index keeps the first one
+	if err := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&pick).Error; err != nil {
+		return nil, err
+	}
+
+	pick = models.PoemOfTheDay{}
+	if err := database.DB.
+		Where("date = ? AND community_level = ?", date, level).
+		Preload("Poem.AuthorData").
+		First(&pick).Error; err != nil {
+		return nil, err
+	}
+	return &pick, nil
+}
+
+// choosePoemOfTheDay deterministically picks a poem for date/level, skipping recent picks
+func choosePoemOfTheDay(date string, level int) (uint, error) {
+	var candidates []uint
+	query := database.DB.Model(&models.Poem{}).Where("is_deleted = ?", false)
+	query = applyCommunityLevelFilter(query, level)
+	if err := query.Order("id ASC").Pluck("id", &candidates).Error; err != nil {
+		return 0, err
+	}
+	if len(candidates) == 0 {
+		return 0, errors.New("no poems available")
+	}
+
+	day, err := helpers.ParseAppDate(date)
+	if err != nil {
+		return 0, err
+	}
+	windowStart := day.AddDate(0, 0, -poemOfTheDayRepeatWindow()).Format(helpers.DateLayout)
+
+	var recentIDs []uint
+	database.DB.Model(&models.PoemOfTheDay{}).
+		Where("community_level = ? AND date >= ? AND date < ?", level, windowStart, date).
+		Pluck("poem_id", &recentIDs)
+
+	recent := make(map[uint]bool, len(recentIDs))
+	for _, id := range recentIDs {
+		recent[id] = true
+	}
+
+	eligible := make([]uint, 0, len(candidates))
+	for _, id := range candidates {
+		if !recent[id] {
+			eligible = append(eligible, id)
+		}
+	}
+	// Every poem was used within the window; allow repeats rather than having no pick
+	if len(eligible) == 0 {
+		eligible = candidates
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(date + ":" + strconv.Itoa(level)))
+	return eligible[h.Sum64()%uint64(len(eligible))], nil
+}
+
+// GetPoemOfTheDay returns today's poem for the viewer's community level
+func GetPoemOfTheDay(c *fiber.Ctx) error {
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	level := communityLevelForRole(roleID)
+	pick, err := getOrPickPoemOfTheDay(helpers.AppToday(), level)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Poem of the day not available",
+		})
+	}
+
+	return c.JSON(pick)
+}
+
+// GetPoemOfTheDayHistory returns past picks for the viewer's community level (newest first)
+func GetPoemOfTheDayHistory(c *fiber.Ctx) error {
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	level := communityLevelForRole(roleID)
+	today := helpers.AppToday()
+	// Make sure today's entry exists before listing
+	if _, err := getOrPickPoemOfTheDay(today, level); err != nil {
+		fmt.Printf("[PoemOfTheDay] date=%s level=%d error=%v\n", today, level, err)
+	}
+
+	params := helpers.GetPaginationParams(c)
+
+	var total int64
+	database.DB.Model(&models.PoemOfTheDay{}).
+		Where("community_level = ? AND date <= ?", level, today).
+		Count(&total)
+
+	picks := []models.PoemOfTheDay{}
+	database.DB.
+		Where("community_level = ? AND date <= ?", level, today).
+		Preload("Poem.AuthorData").
+		Order("date DESC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&picks)
+
+	return c.JSON(helpers.CreatePaginationResponse(picks, total, params.Offset, params.Limit))
+}
+
+// GetPoemOfTheDayPins returns upcoming pinned dates (admin only)
+func GetPoemOfTheDayPins(c *fiber.Ctx) error {
+	pins := []models.PoemOfTheDay{}
+	database.DB.
+		Where("is_pinned = ? AND date >= ?", true, helpers.AppToday()).
+		Preload("Poem.AuthorData").
+		Order("date ASC, community_level ASC").
+		Find(&pins)
+
+	return c.JSON(pins)
+}
+
+// PinPoemOfTheDay pins a poem to a date (admin only).
+// When community_level is omitted the poem is pinned for every level that can see it.
+func PinPoemOfTheDay(c *fiber.Ctx) error {
+	var data struct {
+		Date           string `json:"date"`
+		PoemID         uint   `json:"poem_id"`
+		CommunityLevel int    `json:"community_level"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	day, err := helpers.ParseAppDate(data.Date)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "date must be in YYYY-MM-DD format",
+		})
+	}
+	date := day.Format(helpers.DateLayout)
+	if date < helpers.AppToday() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Past dates cannot be pinned",
+		})
+	}
+
+	var poem models.Poem
+	if err := database.DB.Where("id = ? AND is_deleted = ?", data.PoemID, false).First(&poem).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Poem not found",
+		})
+	}
+
+	var levels []int
+	switch data.CommunityLevel {
+	case 0:
+		levels = []int{communityLevelMembers}
+		if poem.Community == 2 {
+			levels = append(levels, communityLevelPublic)
+		}
+	case communityLevelMembers, communityLevelPublic:
+		levels = []int{data.CommunityLevel}
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "community_level must be 1 (members) or 2 (public)",
+		})
+	}
+
+	for _, level := range levels {
+		if level == communityLevelPublic && poem.Community != 2 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Only public poems can be pinned for the public level",
+			})
+		}
+	}
+
+	userID := GetUserId(c)
+	for _, level := range levels {
+		pin := models.PoemOfTheDay{
+			Date:           date,
+			CommunityLevel: level,
+			PoemID:         poem.ID,
+			IsPinned:       true,
+			PinnedByID:     &userID,
+		}
+		err := database.DB.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "date"}, {Name: "community_level"}},
+			DoUpdates: clause.AssignmentColumns([]string{"poem_id", "is_pinned", "pinned_by_id"}),
+		}).Create(&pin).Error
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to pin poem",
+			})
+		}
+	}
+
+	return GetPoemOfTheDayPins(c)
+}
+
+// UnpinPoemOfTheDay removes a pin (admin only); the date falls back to an automatic pick
+func UnpinPoemOfTheDay(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid pin ID",
+		})
+	}
+
+	var pin models.PoemOfTheDay
+	if err := database.DB.Where("id = ? AND is_pinned = ?", id, true).First(&pin).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Pin not found",
+		})
+	}
+	if pin.Date < helpers.AppToday() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Past picks cannot be changed",
+		})
+	}
+
+	database.DB.Delete(&pin)
+
+	return GetPoemOfTheDayPins(c)
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import "testing"
+
+func TestCommunityLevelForRole(t *testing.T) {
+	tests := []struct {
+		roleID uint
+		want   int
+	}{
+		{1, communityLevelMembers}, // Admin
+		{2, communityLevelMembers}, // Member
+		{3, communityLevelPublic},  // Public user
+		{0, communityLevelPublic},  // Anonymous
+	}
+	for _, tt := range tests {
+		if got := communityLevelForRole(tt.roleID); got != tt.want {
+			t.Errorf("communityLevelForRole(%d) = %d, want %d", tt.roleID, got, tt.want)
+		}
+	}
+}
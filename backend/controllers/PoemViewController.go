@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm/clause"
+)
+
+// botUserAgentMarkers are lowercase user agent fragments of crawlers and scripted clients
+var botUserAgentMarkers = []string{
+	"bot", "crawler", "spider", "slurp", "preview", "headless",
+	"curl", "wget", "python-requests", "go-http-client", "axios", "okhttp",
+}
+
+// poemViewRecorder buffers view events in memory and writes them in batches,
+// so GetPoem never waits on an insert.
+type poemViewRecorder struct {
+	events chan models.PoemView
+
+	mu   sync.Mutex
+	day  string              // Day the seen set and salt belong to
+	seen map[string]struct{} // poemID|viewerKey already queued today
+	salt []byte              // Rotated daily so anonymous viewer keys cannot be linked across days
+}
+
+var (
+	viewRecorder     *poemViewRecorder
+	viewRecorderOnce sync.Once
+)
+
+// poemViews returns the process-wide view recorder
+func poemViews() *poemViewRecorder {
+	viewRecorderOnce.Do(func() {
+		viewRecorder = &poemViewRecorder{
+			events: make(chan models.PoemView, helpers.GetEnvInt("POEM_VIEW_BUFFER_SIZE", 4096)),
+			seen:   make(map[string]struct{}),
+		}
+	})
+	return viewRecorder
+}
+
+// StartPoemViewRecorder drains the view buffer into poem_views. It blocks, so start it with `go`.
+func StartPoemViewRecorder() {
+	recorder := poemViews()
+	interval := helpers.GetEnvDuration("POEM_VIEW_FLUSH_INTERVAL", 10*time.Second)
+	batchSize := helpers.GetEnvInt("POEM_VIEW_BATCH_SIZE", 500)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]models.PoemView, 0, batchSize)
+	for {
+		select {
+		case view := <-recorder.events:
+			batch = append(batch, view)
+			if len(batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		// Views already stored by another instance are skipped by the dedupe index
+		err := database.DB.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(batch, batchSize).Error
+		if err != nil {
+			fmt.Printf("[PoemViews] dropped %d views: %v\n", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+}
+
+// recordPoemView queues a view of the poem for the current request.
+// Bots and clients sending Do Not Track are ignored; a full buffer drops the view.
+func recordPoemView(c *fiber.Ctx, poemID uint) {
+	if c.Get("DNT") == "1" || c.Get("Sec-GPC") == "1" || isBotUserAgent(c.Get(fiber.HeaderUserAgent)) {
+		return
+	}
+
+	recorder := poemViews()
+	today := helpers.AppToday()
+	userID := GetUserId(c)
+
+	recorder.mu.Lock()
+	if recorder.day != today {
+		recorder.day = today
+		recorder.seen = make(map[string]struct{})
+		recorder.salt = make([]byte, 32)
+		rand.Read(recorder.salt)
+	}
+
+	viewerKey := "u:" + strconv.FormatUint(uint64(userID), 10)
+	if userID == 0 {
+		mac := hmac.New(sha256.New, recorder.salt)
+		mac.Write([]byte(c.IP() + "|" + c.Get(fiber.HeaderUserAgent)))
+		viewerKey = "s:" + hex.EncodeToString(mac.Sum(nil))[:40]
+	}
+
+	seenKey := strconv.FormatUint(uint64(poemID), 10) + "|" + viewerKey
+	defer recorder.mu.Unlock()
+	if _, seen := recorder.seen[seenKey]; seen {
+		return
+	}
+
+	// The send never blocks, so it can happen under the lock. A dropped view is not marked
+	// as seen and is counted again on the viewer's next visit.
+	select {
+	case recorder.events <- models.PoemView{PoemID: poemID, AdminID: userID, ViewerKey: viewerKey, Day: today}:
+		recorder.seen[seenKey] = struct{}{}
+	default:
+		fmt.Printf("[PoemViews] buffer full, dropping view of poem %d\n", poemID)
+	}
+}
+
+// isBotUserAgent reports whether the user agent is empty or belongs to a known automated client
+func isBotUserAgent(userAgent string) bool {
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if userAgent == "" {
+		return true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(userAgent, marker) {
+			return true
+		}
+	}
+	return false
+}
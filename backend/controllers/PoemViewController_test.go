@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"backend/models"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIsBotUserAgent(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      bool
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/129.0 Safari/537.36", false},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X) Mobile/15E148", false},
+		{"", true},
+		{"   ", true},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true},
+		{"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php) preview", true},
+		{"curl/8.5.0", true},
+		{"python-requests/2.32.3", true},
+		{"Mozilla/5.0 HeadlessChrome/129.0", true},
+	}
+	for _, tt := range tests {
+		if got := isBotUserAgent(tt.userAgent); got != tt.want {
+			t.Errorf("isBotUserAgent(%q) = %v, want %v", tt.userAgent, got, tt.want)
+		}
+	}
+}
+
+// drainPoemViews empties the view buffer and returns what was queued
+func drainPoemViews() []models.PoemView {
+	var views []models.PoemView
+	for {
+		select {
+		case view := <-poemViews().events:
+			views = append(views, view)
+		default:
+			return views
+		}
+	}
+}
+
+func TestRecordPoemView(t *testing.T) {
+	app := fiber.New()
+	app.Get("/poems/:id", func(c *fiber.Ctx) error {
+		id, _ := c.ParamsInt("id")
+		recordPoemView(c, uint(id))
+		return nil
+	})
+	const browser = "Mozilla/5.0 (X11; Linux x86_64) Firefox/131.0"
+	view := func(path string, headers map[string]string) {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		if _, err := app.Test(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	drainPoemViews()
+
+	view("/poems/7", map[string]string{"User-Agent": browser})
+	view("/poems/7", map[string]string{"User-Agent": browser}) // Same reader, same day
+	view("/poems/8", map[string]string{"User-Agent": browser})
+	view("/poems/7", map[string]string{"User-Agent": "Googlebot/2.1"})
+	view("/poems/9", map[string]string{"User-Agent": browser, "DNT": "1"})
+	view("/poems/9", map[string]string{"User-Agent": browser, "Sec-GPC": "1"})
+
+	views := drainPoemViews()
+	if len(views) != 2 || views[0].PoemID != 7 || views[1].PoemID != 8 {
+		t.Fatalf("queued views = %+v, want one view of poem 7 and one of poem 8", views)
+	}
+	if views[0].AdminID != 0 || views[0].ViewerKey == "" || views[0].ViewerKey != views[1].ViewerKey {
+		t.Errorf("anonymous viewer keys = %q and %q, want the same hashed key", views[0].ViewerKey, views[1].ViewerKey)
+	}
+
+	// A view dropped by a full buffer is not remembered, so the next visit counts
+	recorder := poemViews()
+	for len(recorder.events) < cap(recorder.events) {
+		recorder.events <- models.PoemView{}
+	}
+	view("/poems/10", map[string]string{"User-Agent": browser})
+	drainPoemViews()
+	view("/poems/10", map[string]string{"User-Agent": browser})
+	if views := drainPoemViews(); len(views) != 1 || views[0].PoemID != 10 {
+		t.Errorf("after a dropped view, queued views = %+v, want one view of poem 10", views)
+	}
+}
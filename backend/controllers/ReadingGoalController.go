@@ -0,0 +1,695 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	ws "backend/websocket"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// readingMilestones are the progress percentages that trigger a notification
+var readingMilestones = []int{25, 50, 100}
+
+// YearReadingTotals is what a user read in one calendar year
+type YearReadingTotals struct {
+	Books int64 `json:"books"`
+	Pages int64 `json:"pages"`
+}
+
+// ReadingGoalProgress is a goal together with the progress made towards it
+type ReadingGoalProgress struct {
+	Year          int                 `json:"year"`
+	Goal          *models.ReadingGoal `json:"goal"`
+	Totals        YearReadingTotals   `json:"totals"`
+	BookPercent   float64             `json:"book_percent"`
+	PagePercent   float64             `json:"page_percent"`
+	ExpectedBooks float64             `json:"expected_books"` // Where the user should be today to stay on track
+	ExpectedPages float64             `json:"expected_pages"`
+}
+
+// ChallengeStanding is one member's place on a challenge leaderboard
+type ChallengeStanding struct {
+	Rank        int       `json:"rank"`
+	AdminID     uint      `json:"admin_id"`
+	Username    string    `json:"username"`
+	Image       string    `json:"profile_image"`
+	Value       int64     `json:"value"`
+	Percent     float64   `json:"percent"`
+	JoinedAt    time.Time `json:"joined_at"`
+	IsCompleted bool      `json:"is_completed"`
+}
+
+// yearBounds returns the start of the year and of the next one in the app timezone
+func yearBounds(year int) (time.Time, time.Time) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, helpers.AppLocation())
+	return start, start.AddDate(1, 0, 0)
+}
+
+// queryYear reads ?year=, defaulting to the current year
+func queryYear(c *fiber.Ctx) int {
+	year, err := strconv.Atoi(c.Query("year"))
+	if err != nil || year < 1900 || year > 3000 {
+		return helpers.AppNow().Year()
+	}
+	return year
+}
+
+// readingTotalsForYear counts finished books and read pages of each user in a year.
+// Pages come from reading sessions; finished books without any logged session count
+// with their page total so users who only mark books as read still make progress.
+func readingTotalsForYear(userIDs []uint, year int) map[uint]YearReadingTotals {
+	totals := make(map[uint]YearReadingTotals, len(userIDs))
+	if len(userIDs) == 0 {
+		return totals
+	}
+	start, end := yearBounds(year)
+
+	var rows []struct {
+		AdminID uint
+		Value   int64
+	}
+	database.DB.Model(&models.BookProgress{}).
+		Select("admin_id, COUNT(*) AS value").
+		Where("admin_id IN ? AND status = ? AND finished_at >= ? AND finished_at < ?", userIDs, models.ShelfFinished, start, end).
+		Group("admin_id").
+		Scan(&rows)
+	for _, row := range rows {
+		entry := totals[row.AdminID]
+		entry.Books = row.Value
+		totals[row.AdminID] = entry
+	}
+
+	rows = nil
+	database.DB.Raw(`SELECT admin_id, SUM(pages) AS value FROM (
+			SELECT admin_id, SUM(pages_read) AS pages
+			FROM reading_sessions
+			WHERE admin_id IN @users AND read_at >= @start AND read_at < @end
+			GROUP BY admin_id
+			UNION ALL
+			SELECT bp.admin_id, SUM(bp.total_pages)
+			FROM book_progresses bp
+			WHERE bp.admin_id IN @users AND bp.status = @finished AND bp.finished_at >= @start AND bp.finished_at < @end
+			AND NOT EXISTS (
+				SELECT 1 FROM reading_sessions rs WHERE rs.admin_id = bp.admin_id AND rs.book_id = bp.book_id
+			)
+			GROUP BY bp.admin_id
+		) pages GROUP BY admin_id`, map[string]interface{}{
+		"users":    userIDs,
+		"start":    start,
+		"end":      end,
+		"finished": models.ShelfFinished,
+	}).Scan(&rows)
+	for _, row := range rows {
+		entry := totals[row.AdminID]
+		entry.Pages = row.Value
+		totals[row.AdminID] = entry
+	}
+	return totals
+}
+
+// percentOf returns value as a percentage of target, 0 without a target
+func percentOf(value int64, target int) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return roundOne(float64(value) / float64(target) * 100)
+}
+
+// reachedMilestone returns the highest milestone value has reached, or 0
+func reachedMilestone(value int64, target int) int {
+	reached := 0
+	if target <= 0 {
+		return reached
+	}
+	for _, milestone := range readingMilestones {
+		if value*100 >= int64(milestone*target) {
+			reached = milestone
+		}
+	}
+	return reached
+}
+
+// yearElapsed returns how much of the year has passed, from 0 to 1
+func yearElapsed(year int) float64 {
+	start, end := yearBounds(year)
+	now := helpers.AppNow()
+	switch {
+	case now.Before(start):
+		return 0
+	case !now.Before(end):
+		return 1
+	}
+	return now.Sub(start).Seconds() / end.Sub(start).Seconds()
+}
+
+// goalProgress combines a goal (which may be nil) with the user's totals for its year
+func goalProgress(userID uint, year int, goal *models.ReadingGoal) ReadingGoalProgress {
+	progress := ReadingGoalProgress{
+		Year:   year,
+		Goal:   goal,
+		Totals: readingTotalsForYear([]uint{userID}, year)[userID],
+	}
+	if goal != nil {
+		elapsed := yearElapsed(year)
+		progress.BookPercent = percentOf(progress.Totals.Books, goal.BookTarget)
+		progress.PagePercent = percentOf(progress.Totals.Pages, goal.PageTarget)
+		progress.ExpectedBooks = roundOne(float64(goal.BookTarget) * elapsed)
+		progress.ExpectedPages = roundOne(float64(goal.PageTarget) * elapsed)
+	}
+	return progress
+}
+
+// checkReadingMilestones notifies the user about goal and challenge milestones reached in the
+// current year. Each milestone is claimed with a conditional update so it is sent only once.
+func checkReadingMilestones(userID uint) {
+	year := helpers.AppNow().Year()
+	totals := readingTotalsForYear([]uint{userID}, year)[userID]
+
+	var goal models.ReadingGoal
+	if err := database.DB.Where("admin_id = ? AND year = ?", userID, year).First(&goal).Error; err == nil {
+		goalMetrics := []struct {
+			metric string
+			column string
+			value  int64
+			target int
+		}{
+			{models.ChallengeMetricBooks, "notified_book_milestone", totals.Books, goal.BookTarget},
+			{models.ChallengeMetricPages, "notified_page_milestone", totals.Pages, goal.PageTarget},
+		}
+		for _, metric := range goalMetrics {
+			milestone := reachedMilestone(metric.value, metric.target)
+			if milestone == 0 {
+				continue
+			}
+			result := database.DB.Model(&models.ReadingGoal{}).
+				Where("id = ? AND "+metric.column+" < ?", goal.ID, milestone).
+				Update(metric.column, milestone)
+			if result.Error != nil || result.RowsAffected == 0 {
+				continue
+			}
+			fmt.Printf("[WebSocket] Sending reading_goal_milestone to user %d: %s %d%%\n", userID, metric.metric, milestone)
+			ws.GlobalHub.SendToUser(userID, "reading_goal_milestone", map[string]interface{}{
+				"year":      year,
+				"metric":    metric.metric,
+				"milestone": milestone,
+				"value":     metric.value,
+				"target":    metric.target,
+			})
+		}
+	}
+
+	var challenges []struct {
+		ID                uint
+		Name              string
+		Metric            string
+		Target            int
+		NotifiedMilestone int
+	}
+	database.DB.Model(&models.ReadingChallenge{}).
+		Select("reading_challenges.id, reading_challenges.name, reading_challenges.metric, reading_challenges.target, reading_challenge_members.notified_milestone").
+		Joins("JOIN reading_challenge_members ON reading_challenge_members.challenge_id = reading_challenges.id").
+		Where("reading_challenge_members.admin_id = ? AND reading_challenges.year = ?", userID, year).
+		Scan(&challenges)
+	for _, challenge := range challenges {
+		value := totals.Books
+		if challenge.Metric == models.ChallengeMetricPages {
+			value = totals.Pages
+		}
+		milestone := reachedMilestone(value, challenge.Target)
+		if milestone <= challenge.NotifiedMilestone {
+			continue
+		}
+		result := database.DB.Model(&models.ReadingChallengeMember{}).
+			Where("challenge_id = ? AND admin_id = ? AND notified_milestone < ?", challenge.ID, userID, milestone).
+			Update("notified_milestone", milestone)
+		if result.Error != nil || result.RowsAffected == 0 {
+			continue
+		}
+		fmt.Printf("[WebSocket] Sending reading_challenge_milestone to user %d: challenge %d %d%%\n", userID, challenge.ID, milestone)
+		ws.GlobalHub.SendToUser(userID, "reading_challenge_milestone", map[string]interface{}{
+			"challenge_id": challenge.ID,
+			"name":         challenge.Name,
+			"metric":       challenge.Metric,
+			"milestone":    milestone,
+			"value":        value,
+			"target":       challenge.Target,
+		})
+	}
+}
+
+// visibleChallengesQuery returns challenges the user may see and join: their own, their
+// friends' and the ones they are a member of. Admins see all.
+func visibleChallengesQuery(userID uint, roleID uint) *gorm.DB {
+	query := database.DB.Model(&models.ReadingChallenge{})
+	if roleID != 1 {
+		query = query.Where(
+			"reading_challenges.owner_id IN ? OR reading_challenges.id IN (SELECT challenge_id FROM reading_challenge_members WHERE admin_id = ?)",
+			GetFriendIDs(userID), userID,
+		)
+	}
+	return query
+}
+
+// attachChallengeMembership fills in member counts and whether the user joined
+func attachChallengeMembership(challenges []models.ReadingChallenge, userID uint) {
+	if len(challenges) == 0 {
+		return
+	}
+	ids := make([]uint, 0, len(challenges))
+	for _, challenge := range challenges {
+		ids = append(ids, challenge.ID)
+	}
+
+	var counts []struct {
+		ChallengeID uint
+		Count       int64
+	}
+	database.DB.Model(&models.ReadingChallengeMember{}).
+		Select("challenge_id, COUNT(*) AS count").
+		Where("challenge_id IN ?", ids).
+		Group("challenge_id").
+		Scan(&counts)
+	countByID := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countByID[count.ChallengeID] = count.Count
+	}
+
+	var joined []uint
+	database.DB.Model(&models.ReadingChallengeMember{}).
+		Where("challenge_id IN ? AND admin_id = ?", ids, userID).
+		Pluck("challenge_id", &joined)
+	joinedIDs := make(map[uint]bool, len(joined))
+	for _, id := range joined {
+		joinedIDs[id] = true
+	}
+
+	for i := range challenges {
+		challenges[i].MemberCount = countByID[challenges[i].ID]
+		challenges[i].Joined = joinedIDs[challenges[i].ID]
+	}
+}
+
+// challengeLeaderboard ranks the members of a challenge by their progress, ties by join date
+func challengeLeaderboard(challenge models.ReadingChallenge) []ChallengeStanding {
+	var members []models.ReadingChallengeMember
+	database.DB.Where("challenge_id = ?", challenge.ID).Find(&members)
+	if len(members) == 0 {
+		return []ChallengeStanding{}
+	}
+
+	userIDs := make([]uint, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.AdminID)
+	}
+	var admins []models.Admin
+	database.DB.Select("id", "username", "profile_image").Where("id IN ?", userIDs).Find(&admins)
+	adminsByID := make(map[uint]models.Admin, len(admins))
+	for _, admin := range admins {
+		adminsByID[admin.ID] = admin
+	}
+	totals := readingTotalsForYear(userIDs, challenge.Year)
+
+	standings := make([]ChallengeStanding, 0, len(members))
+	for _, member := range members {
+		value := totals[member.AdminID].Books
+		if challenge.Metric == models.ChallengeMetricPages {
+			value = totals[member.AdminID].Pages
+		}
+		admin := adminsByID[member.AdminID]
+		standings = append(standings, ChallengeStanding{
+			AdminID:     member.AdminID,
+			Username:    admin.Username,
+			Image:       admin.ProfileImage,
+			Value:       value,
+			Percent:     percentOf(value, challenge.Target),
+			JoinedAt:    member.JoinedAt,
+			IsCompleted: value >= int64(challenge.Target),
+		})
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Value != standings[j].Value {
+			return standings[i].Value > standings[j].Value
+		}
+		return standings[i].JoinedAt.Before(standings[j].JoinedAt)
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if i > 0 && standings[i].Value == standings[i-1].Value {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+	return standings
+}
+
+// SetReadingGoal creates or updates the session user's goal for a year (default: current year)
+func SetReadingGoal(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+
+	var data struct {
+		Year       int `json:"year"`
+		BookTarget int `json:"book_target"`
+		PageTarget int `json:"page_target"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	if data.Year == 0 {
+		data.Year = helpers.AppNow().Year()
+	}
+	if data.Year < 1900 || data.Year > 3000 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz yıl",
+		})
+	}
+	if data.BookTarget < 0 || data.PageTarget < 0 || (data.BookTarget == 0 && data.PageTarget == 0) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Kitap veya sayfa hedefi gerekli",
+		})
+	}
+
+	goal := models.ReadingGoal{
+		AdminID:    userID,
+		Year:       data.Year,
+		BookTarget: data.BookTarget,
+		PageTarget: data.PageTarget,
+	}
+	// A changed target starts its milestones over
+	err := database.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "admin_id"}, {Name: "year"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"book_target":             data.BookTarget,
+			"page_target":             data.PageTarget,
+			"notified_book_milestone": 0,
+			"notified_page_milestone": 0,
+			"updated_at":              helpers.AppNow(),
+		}),
+	}).Create(&goal).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Okuma hedefi kaydedilemedi",
+		})
+	}
+	database.DB.Where("admin_id = ? AND year = ?", userID, data.Year).First(&goal)
+
+	// Progress made before the goal was set counts right away
+	if goal.Year == helpers.AppNow().Year() {
+		checkReadingMilestones(userID)
+	}
+
+	return c.JSON(goalProgress(userID, goal.Year, &goal))
+}
+
+// GetReadingGoal returns the session user's goal for ?year= with the progress towards it.
+// Without a goal only the year's totals are returned.
+func GetReadingGoal(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	year := queryYear(c)
+
+	var goal *models.ReadingGoal
+	var stored models.ReadingGoal
+	if err := database.DB.Where("admin_id = ? AND year = ?", userID, year).First(&stored).Error; err == nil {
+		goal = &stored
+	}
+	return c.JSON(goalProgress(userID, year, goal))
+}
+
+// DeleteReadingGoal removes the session user's goal for a year
+func DeleteReadingGoal(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	year, _ := strconv.Atoi(c.Params("year"))
+
+	result := database.DB.Where("admin_id = ? AND year = ?", userID, year).Delete(&models.ReadingGoal{})
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Okuma hedefi silinemedi",
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Okuma hedefi bulunamadı",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Okuma hedefi silindi",
+	})
+}
+
+// CreateReadingChallenge creates a challenge owned by the session user, who joins it right away
+func CreateReadingChallenge(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+
+	var data struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Year        int    `json:"year"`
+		Metric      string `json:"metric"`
+		Target      int    `json:"target"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+
+	sanitizer := security.NewSanitizer()
+	name := strings.TrimSpace(sanitizer.SanitizePlainText(data.Name))
+	if name == "" || len([]rune(name)) > 100 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Yarışma adı 1-100 karakter olmalı",
+		})
+	}
+	if data.Year == 0 {
+		data.Year = helpers.AppNow().Year()
+	}
+	if data.Year < helpers.AppNow().Year() || data.Year > 3000 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz yıl",
+		})
+	}
+	if data.Metric != models.ChallengeMetricBooks && data.Metric != models.ChallengeMetricPages {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "metric books veya pages olmalı",
+		})
+	}
+	if data.Target <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Hedef sıfırdan büyük olmalı",
+		})
+	}
+
+	challenge := models.ReadingChallenge{
+		OwnerID:     userID,
+		Name:        name,
+		Description: sanitizer.SanitizePlainText(data.Description),
+		Year:        data.Year,
+		Metric:      data.Metric,
+		Target:      data.Target,
+	}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&challenge).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ReadingChallengeMember{
+			ChallengeID: challenge.ID,
+			AdminID:     userID,
+			JoinedAt:    helpers.AppNow(),
+		}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Yarışma oluşturulamadı",
+		})
+	}
+
+	challenge.MemberCount = 1
+	challenge.Joined = true
+	return c.Status(fiber.StatusCreated).JSON(challenge)
+}
+
+// GetReadingChallenges lists the challenges the session user can see, optionally for ?year=
+func GetReadingChallenges(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+	params := helpers.GetPaginationParams(c)
+
+	query := visibleChallengesQuery(userID, roleID)
+	if c.Query("year") != "" {
+		query = query.Where("reading_challenges.year = ?", queryYear(c))
+	}
+
+	var total int64
+	query.Count(&total)
+
+	challenges := []models.ReadingChallenge{}
+	query.Preload("Owner", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "profile_image")
+	}).
+		Order("reading_challenges.year DESC, reading_challenges.created_at DESC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&challenges)
+	attachChallengeMembership(challenges, userID)
+
+	return c.JSON(helpers.CreatePaginationResponse(challenges, total, params.Offset, params.Limit))
+}
+
+// GetReadingChallenge returns a challenge with its leaderboard
+func GetReadingChallenge(c *fiber.Ctx) error {
+	challengeID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var challenge models.ReadingChallenge
+	if err := visibleChallengesQuery(userID, roleID).
+		Preload("Owner", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "profile_image")
+		}).
+		First(&challenge, challengeID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yarışma bulunamadı",
+		})
+	}
+	challenges := []models.ReadingChallenge{challenge}
+	attachChallengeMembership(challenges, userID)
+
+	return c.JSON(fiber.Map{
+		"challenge":   challenges[0],
+		"leaderboard": challengeLeaderboard(challenge),
+	})
+}
+
+// JoinReadingChallenge adds the session user to a challenge owned by a friend
+func JoinReadingChallenge(c *fiber.Ctx) error {
+	challengeID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+
+	var challenge models.ReadingChallenge
+	if err := database.DB.First(&challenge, challengeID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yarışma bulunamadı",
+		})
+	}
+	if challenge.OwnerID != userID && !AreFriends(userID, challenge.OwnerID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Sadece arkadaşlarınızın yarışmalarına katılabilirsiniz",
+		})
+	}
+	if challenge.Year < helpers.AppNow().Year() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Bu yarışma sona erdi",
+		})
+	}
+
+	err := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ReadingChallengeMember{
+		ChallengeID: challenge.ID,
+		AdminID:     userID,
+		JoinedAt:    helpers.AppNow(),
+	}).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Yarışmaya katılınamadı",
+		})
+	}
+
+	// Members who already passed a milestone hear about it once
+	checkReadingMilestones(userID)
+
+	return c.JSON(fiber.Map{
+		"message":     "Yarışmaya katıldınız",
+		"leaderboard": challengeLeaderboard(challenge),
+	})
+}
+
+// LeaveReadingChallenge removes the session user from a challenge. Owners delete it instead.
+func LeaveReadingChallenge(c *fiber.Ctx) error {
+	challengeID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+
+	var challenge models.ReadingChallenge
+	if err := database.DB.First(&challenge, challengeID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yarışma bulunamadı",
+		})
+	}
+	if challenge.OwnerID == userID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Yarışmanın sahibi ayrılamaz, yarışmayı silebilirsiniz",
+		})
+	}
+
+	result := database.DB.Where("challenge_id = ? AND admin_id = ?", challenge.ID, userID).Delete(&models.ReadingChallengeMember{})
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Yarışmadan ayrılınamadı",
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Bu yarışmaya katılmadınız",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Yarışmadan ayrıldınız",
+	})
+}
+
+// DeleteReadingChallenge deletes a challenge and its memberships (owner or admin)
+func DeleteReadingChallenge(c *fiber.Ctx) error {
+	challengeID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var challenge models.ReadingChallenge
+	if err := database.DB.First(&challenge, challengeID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Yarışma bulunamadı",
+		})
+	}
+	if challenge.OwnerID != userID && !isModerator(roleID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Bu yarışmayı silme yetkiniz yok",
+		})
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("challenge_id = ?", challenge.ID).Delete(&models.ReadingChallengeMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&challenge).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Yarışma silinemedi",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Yarışma silindi",
+	})
+}
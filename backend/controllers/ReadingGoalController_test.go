@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestReachedMilestone(t *testing.T) {
+	tests := []struct {
+		value  int64
+		target int
+		want   int
+	}{
+		{0, 20, 0},
+		{4, 20, 0},
+		{5, 20, 25},
+		{9, 20, 25},
+		{10, 20, 50},
+		{19, 20, 50},
+		{20, 20, 100},
+		{35, 20, 100},
+		{1, 3, 25},
+		{2, 3, 50},
+		{10, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := reachedMilestone(tt.value, tt.target); got != tt.want {
+			t.Errorf("reachedMilestone(%d, %d) = %d, want %d", tt.value, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestPercentOf(t *testing.T) {
+	tests := []struct {
+		value  int64
+		target int
+		want   float64
+	}{
+		{0, 12, 0},
+		{3, 12, 25},
+		{1, 3, 33.3},
+		{15, 12, 125},
+		{5, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := percentOf(tt.value, tt.target); got != tt.want {
+			t.Errorf("percentOf(%d, %d) = %v, want %v", tt.value, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestSetReadingGoalValidation(t *testing.T) {
+	app := fiber.New()
+	app.Post("/reading-goal", SetReadingGoal)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"no target", `{"year":2024}`},
+		{"negative target", `{"year":2024,"book_target":-1,"page_target":500}`},
+		{"year too early", `{"year":1850,"book_target":12}`},
+		{"year too late", `{"year":3001,"book_target":12}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/reading-goal", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, fiber.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,599 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// maxSessionMinutes is the longest reading session that can be logged
+const maxSessionMinutes = 24 * 60
+
+// BookProgressStats are derived figures for one book's progress
+type BookProgressStats struct {
+	PagesRead         int        `json:"pages_read"`
+	PagesRemaining    int        `json:"pages_remaining"`
+	DaysReading       int        `json:"days_reading"`
+	PagesPerDay       float64    `json:"pages_per_day"`
+	PagesPerHour      float64    `json:"pages_per_hour"` // Only from sessions with a duration
+	Sessions          int64      `json:"sessions"`
+	MinutesRead       int64      `json:"minutes_read"`
+	ProjectedFinishAt *string    `json:"projected_finish_at"` // YYYY-MM-DD, nil when finished or without pace
+	LastReadAt        *time.Time `json:"last_read_at"`
+}
+
+// BookProgressWithStats is a progress record together with its statistics
+type BookProgressWithStats struct {
+	models.BookProgress
+	Stats BookProgressStats `json:"stats"`
+}
+
+// DailyReadingProgress is the reading done on a single day
+type DailyReadingProgress struct {
+	Date     string `json:"date"`
+	Pages    int64  `json:"pages"`
+	Minutes  int64  `json:"minutes"`
+	Sessions int64  `json:"sessions"`
+}
+
+// ReadingOverview is a user's reading figures across all books
+type ReadingOverview struct {
+	BooksInProgress int64                  `json:"books_in_progress"`
+	BooksFinished   int64                  `json:"books_finished"`
+	PagesRead       int64                  `json:"pages_read"`
+	MinutesRead     int64                  `json:"minutes_read"`
+	Sessions        int64                  `json:"sessions"`
+	Days            int                    `json:"days"`
+	From            string                 `json:"from"`
+	WindowPages     int64                  `json:"window_pages"`
+	WindowMinutes   int64                  `json:"window_minutes"`
+	ActiveDays      int                    `json:"active_days"`
+	PagesPerDay     float64                `json:"pages_per_day"`
+	PagesPerHour    float64                `json:"pages_per_hour"`
+	Series          []DailyReadingProgress `json:"series"`
+}
+
+// sessionTotals aggregates the sessions of one book
+type sessionTotals struct {
+	BookID     uint
+	Sessions   int64
+	Minutes    int64
+	TimedPages int64 // Pages of sessions that have a duration
+	LastReadAt *time.Time
+}
+
+// findVisibleBook returns the book if it exists and the role can see it
+func findVisibleBook(bookID uint, roleID uint) (models.Book, error) {
+	var book models.Book
+	query := database.DB.Where("id = ? AND is_deleted = ?", bookID, false)
+	query = applyCommunityFilterForBook(query, roleID)
+	err := query.First(&book).Error
+	return book, err
+}
+
+// loadOrStartProgress returns the user's progress on the book, or a new unsaved one when
+// there is none yet
+func loadOrStartProgress(tx *gorm.DB, userID uint, book models.Book) (models.BookProgress, error) {
+	var progress models.BookProgress
+	err := tx.Where("admin_id = ? AND book_id = ?", userID, book.ID).First(&progress).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.BookProgress{AdminID: userID, BookID: book.ID, TotalPages: book.Page}, nil
+	}
+	return progress, err
+}
+
+// settleProgress clamps the current page, recomputes the percentage, fills in the start date
+// and moves the book between the reading and finished shelves when the page calls for it
+func settleProgress(progress *models.BookProgress, now time.Time) {
+	if progress.CurrentPage < 0 {
+		progress.CurrentPage = 0
+	}
+	if progress.TotalPages > 0 && progress.CurrentPage > progress.TotalPages {
+		progress.CurrentPage = progress.TotalPages
+	}
+	if progress.TotalPages > 0 {
+		progress.Percentage = math.Round(float64(progress.CurrentPage)/float64(progress.TotalPages)*10000) / 100
+	}
+
+	if progress.StartedAt == nil && (progress.CurrentPage > 0 || progress.FinishedAt != nil) {
+		progress.StartedAt = &now
+	}
+
+	complete := progress.TotalPages > 0 && progress.CurrentPage >= progress.TotalPages
+	switch {
+	case progress.Status == models.ShelfFinished && progress.TotalPages > 0 && !complete:
+		// Moved back from the last page: reading again
+		applyShelfStatus(progress, models.ShelfReading, now)
+	case (complete || progress.FinishedAt != nil) && progress.Status != models.ShelfFinished:
+		applyShelfStatus(progress, models.ShelfFinished, now)
+	case progress.Status == "" || (progress.Status == models.ShelfWantToRead && progress.CurrentPage > 0):
+		applyShelfStatus(progress, models.ShelfReading, now)
+	}
+}
+
+// errFinishedBeforeStarted rejects a progress update that would end a book before starting it
+var errFinishedBeforeStarted = errors.New("finished_at before started_at")
+
+// parseProgressDate parses an optional YYYY-MM-DD date in the app timezone
+func parseProgressDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	date, err := helpers.ParseAppDate(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// loadSessionTotals aggregates the user's sessions per book
+func loadSessionTotals(userID uint, bookIDs []uint) map[uint]sessionTotals {
+	totals := make(map[uint]sessionTotals, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return totals
+	}
+
+	var rows []sessionTotals
+	database.DB.Model(&models.ReadingSession{}).
+		Select(`book_id, COUNT(*) AS sessions, COALESCE(SUM(duration_minutes), 0) AS minutes,
+			COALESCE(SUM(CASE WHEN duration_minutes > 0 THEN pages_read ELSE 0 END), 0) AS timed_pages,
+			MAX(read_at) AS last_read_at`).
+		Where("admin_id = ? AND book_id IN ?", userID, bookIDs).
+		Group("book_id").
+		Scan(&rows)
+	for _, row := range rows {
+		totals[row.BookID] = row
+	}
+	return totals
+}
+
+// bookProgressStats derives pace and projection for one book
+func bookProgressStats(progress models.BookProgress, totals sessionTotals, now time.Time) BookProgressStats {
+	stats := BookProgressStats{
+		PagesRead:   progress.CurrentPage,
+		Sessions:    totals.Sessions,
+		MinutesRead: totals.Minutes,
+		LastReadAt:  totals.LastReadAt,
+	}
+	if progress.TotalPages > progress.CurrentPage {
+		stats.PagesRemaining = progress.TotalPages - progress.CurrentPage
+	}
+	if totals.Minutes > 0 {
+		stats.PagesPerHour = roundOne(float64(totals.TimedPages) / float64(totals.Minutes) * 60)
+	}
+	if progress.StartedAt == nil {
+		return stats
+	}
+
+	end := now
+	if progress.FinishedAt != nil {
+		end = *progress.FinishedAt
+	}
+	stats.DaysReading = calendarDaysBetween(*progress.StartedAt, end) + 1
+	stats.PagesPerDay = roundOne(float64(progress.CurrentPage) / float64(stats.DaysReading))
+
+	if progress.FinishedAt == nil && stats.PagesRemaining > 0 && stats.PagesPerDay > 0 {
+		daysLeft := int(math.Ceil(float64(stats.PagesRemaining) / (float64(progress.CurrentPage) / float64(stats.DaysReading))))
+		projected := now.In(helpers.AppLocation()).AddDate(0, 0, daysLeft).Format(helpers.DateLayout)
+		stats.ProjectedFinishAt = &projected
+	}
+	return stats
+}
+
+// calendarDaysBetween counts midnights between two times in the app timezone
+func calendarDaysBetween(from, to time.Time) int {
+	location := helpers.AppLocation()
+	from = from.In(location)
+	to = to.In(location)
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(toDay.Sub(fromDay).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// roundOne rounds to one decimal place
+func roundOne(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// withProgressStats attaches statistics to progress records using one aggregate query
+func withProgressStats(userID uint, progress []models.BookProgress) []BookProgressWithStats {
+	bookIDs := make([]uint, 0, len(progress))
+	for _, entry := range progress {
+		bookIDs = append(bookIDs, entry.BookID)
+	}
+	totals := loadSessionTotals(userID, bookIDs)
+
+	now := helpers.AppNow()
+	result := make([]BookProgressWithStats, 0, len(progress))
+	for _, entry := range progress {
+		result = append(result, BookProgressWithStats{
+			BookProgress: entry,
+			Stats:        bookProgressStats(entry, totals[entry.BookID], now),
+		})
+	}
+	return result
+}
+
+// UpdateBookProgress sets the current page (or percentage) of a book for the session user.
+// Reaching the last page moves the book to the finished shelf and adds it to the read list.
+func UpdateBookProgress(c *fiber.Ctx) error {
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data struct {
+		CurrentPage *int     `json:"current_page"`
+		Percentage  *float64 `json:"percentage"`
+		TotalPages  *int     `json:"total_pages"`
+		StartedAt   *string  `json:"started_at"`
+		FinishedAt  *string  `json:"finished_at"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	startedAt, err := parseProgressDate(data.StartedAt)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "started_at YYYY-MM-DD biçiminde olmalı",
+		})
+	}
+	finishedAt, err := parseProgressDate(data.FinishedAt)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "finished_at YYYY-MM-DD biçiminde olmalı",
+		})
+	}
+	now := helpers.AppNow()
+	if (startedAt != nil && startedAt.After(now)) || (finishedAt != nil && finishedAt.After(now)) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Gelecek tarihli okuma kaydedilemez",
+		})
+	}
+	if data.Percentage != nil && (*data.Percentage < 0 || *data.Percentage > 100) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Yüzde 0 ile 100 arasında olmalı",
+		})
+	}
+	if data.TotalPages != nil && *data.TotalPages < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Sayfa sayısı negatif olamaz",
+		})
+	}
+
+	book, err := findVisibleBook(uint(bookID), roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kitap bulunamadı",
+		})
+	}
+
+	var progress models.BookProgress
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if progress, err = loadOrStartProgress(tx, userID, book); err != nil {
+			return err
+		}
+		previous := progress.Status
+
+		if data.TotalPages != nil && *data.TotalPages > 0 {
+			progress.TotalPages = *data.TotalPages
+		}
+		switch {
+		case data.CurrentPage != nil:
+			progress.CurrentPage = *data.CurrentPage
+		case data.Percentage != nil && progress.TotalPages > 0:
+			progress.CurrentPage = int(math.Round(*data.Percentage / 100 * float64(progress.TotalPages)))
+		case data.Percentage != nil:
+			// Without a page count only the percentage can be kept
+			progress.Percentage = *data.Percentage
+		}
+		if startedAt != nil {
+			progress.StartedAt = startedAt
+		}
+		if finishedAt != nil {
+			progress.FinishedAt = finishedAt
+			if progress.TotalPages > 0 {
+				progress.CurrentPage = progress.TotalPages
+			}
+		}
+		// Checked against the stored dates too, since a request may send only one of them
+		if progress.StartedAt != nil && progress.FinishedAt != nil && progress.FinishedAt.Before(*progress.StartedAt) {
+			return errFinishedBeforeStarted
+		}
+
+		settleProgress(&progress, now)
+		return saveProgress(tx, &progress, previous, now)
+	})
+	if errors.Is(err, errFinishedBeforeStarted) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "finished_at, started_at tarihinden önce olamaz",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Okuma durumu kaydedilemedi",
+		})
+	}
+
+	fmt.Printf("[ReadingProgress] userID=%d bookID=%d page=%d/%d\n", userID, book.ID, progress.CurrentPage, progress.TotalPages)
+	checkReadingMilestones(userID)
+
+	return c.JSON(withProgressStats(userID, []models.BookProgress{progress})[0])
+}
+
+// AddReadingSession logs a sitting with a book and advances the progress to its last page.
+// The session is given either as end_page or as pages_read from start_page (default: current page).
+func AddReadingSession(c *fiber.Ctx) error {
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data struct {
+		StartPage       *int    `json:"start_page"`
+		EndPage         *int    `json:"end_page"`
+		PagesRead       *int    `json:"pages_read"`
+		DurationMinutes int     `json:"duration_minutes"`
+		ReadAt          *string `json:"read_at"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	if data.EndPage == nil && data.PagesRead == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "end_page veya pages_read gerekli",
+		})
+	}
+	if data.DurationMinutes < 0 || data.DurationMinutes > maxSessionMinutes {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Süre 0 ile 1440 dakika arasında olmalı",
+		})
+	}
+	readAt, err := parseProgressDate(data.ReadAt)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "read_at YYYY-MM-DD biçiminde olmalı",
+		})
+	}
+	now := helpers.AppNow()
+	if readAt == nil {
+		readAt = &now
+	}
+	if readAt.After(now) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Gelecek tarihli okuma kaydedilemez",
+		})
+	}
+
+	book, err := findVisibleBook(uint(bookID), roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kitap bulunamadı",
+		})
+	}
+
+	var progress models.BookProgress
+	var session models.ReadingSession
+	invalid := false
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if progress, err = loadOrStartProgress(tx, userID, book); err != nil {
+			return err
+		}
+		previous := progress.Status
+
+		start := progress.CurrentPage
+		if data.StartPage != nil {
+			start = *data.StartPage
+		}
+		var end int
+		if data.EndPage != nil {
+			end = *data.EndPage
+		} else {
+			end = start + *data.PagesRead
+		}
+		if start < 0 || end <= start || (progress.TotalPages > 0 && end > progress.TotalPages) {
+			invalid = true
+			return fmt.Errorf("invalid page range %d-%d", start, end)
+		}
+
+		session = models.ReadingSession{
+			AdminID:         userID,
+			BookID:          book.ID,
+			StartPage:       start,
+			EndPage:         end,
+			PagesRead:       end - start,
+			DurationMinutes: data.DurationMinutes,
+			ReadAt:          *readAt,
+		}
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+
+		if end > progress.CurrentPage {
+			progress.CurrentPage = end
+		}
+		if progress.StartedAt == nil || readAt.Before(*progress.StartedAt) {
+			progress.StartedAt = readAt
+		}
+		if progress.Status == models.ShelfAbandoned {
+			// Logging a session picks an abandoned book back up
+			applyShelfStatus(&progress, models.ShelfReading, now)
+		}
+		settleProgress(&progress, now)
+		return saveProgress(tx, &progress, previous, now)
+	})
+	if invalid {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz sayfa aralığı",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Okuma oturumu kaydedilemedi",
+		})
+	}
+
+	checkReadingMilestones(userID)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"session":  session,
+		"progress": withProgressStats(userID, []models.BookProgress{progress})[0],
+	})
+}
+
+// DeleteReadingSession removes one of the user's sessions. Progress is a page position
+// and is left as it is.
+func DeleteReadingSession(c *fiber.Ctx) error {
+	sessionID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+
+	result := database.DB.Where("id = ? AND admin_id = ?", sessionID, userID).Delete(&models.ReadingSession{})
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Okuma oturumu silinemedi",
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Okuma oturumu bulunamadı",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Okuma oturumu silindi",
+	})
+}
+
+// GetBookProgress returns the session user's progress on a book with its statistics and sessions
+func GetBookProgress(c *fiber.Ctx) error {
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+
+	var progress models.BookProgress
+	if err := database.DB.Preload("Book.AuthorData").
+		Where("admin_id = ? AND book_id = ?", userID, bookID).
+		First(&progress).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Okuma kaydı bulunamadı",
+		})
+	}
+
+	sessions := []models.ReadingSession{}
+	database.DB.Where("admin_id = ? AND book_id = ?", userID, bookID).
+		Order("read_at DESC, id DESC").
+		Find(&sessions)
+
+	return c.JSON(fiber.Map{
+		"progress": withProgressStats(userID, []models.BookProgress{progress})[0],
+		"sessions": sessions,
+	})
+}
+
+// GetReadingProgress lists the session user's books with progress, most recently updated first.
+// ?status= filters the list to one shelf (want_to_read, reading, finished, abandoned).
+func GetReadingProgress(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	params := helpers.GetPaginationParams(c)
+
+	query := database.DB.Model(&models.BookProgress{}).
+		Joins("JOIN books ON books.id = book_progresses.book_id AND books.is_deleted = ?", false).
+		Where("book_progresses.admin_id = ?", userID)
+	if status := c.Query("status"); isShelfStatus(status) {
+		query = query.Where("book_progresses.status = ?", status)
+	}
+
+	var total int64
+	query.Count(&total)
+
+	progress := []models.BookProgress{}
+	query.Preload("Book.AuthorData").
+		Order("book_progresses.updated_at DESC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&progress)
+
+	return c.JSON(helpers.CreatePaginationResponse(withProgressStats(userID, progress), total, params.Offset, params.Limit))
+}
+
+// GetReadingStats returns the session user's overall reading statistics and a daily
+// series of logged sessions over ?days= (default 30)
+func GetReadingStats(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	days, fromDay, since := analyticsWindow(c)
+
+	overview := ReadingOverview{Days: days, From: fromDay}
+	database.DB.Model(&models.BookProgress{}).
+		Where("admin_id = ? AND status = ?", userID, models.ShelfReading).
+		Count(&overview.BooksInProgress)
+	database.DB.Model(&models.BookProgress{}).
+		Where("admin_id = ? AND status = ?", userID, models.ShelfFinished).
+		Count(&overview.BooksFinished)
+	database.DB.Model(&models.BookProgress{}).
+		Select("COALESCE(SUM(current_page), 0)").
+		Where("admin_id = ?", userID).
+		Scan(&overview.PagesRead)
+	var allSessions sessionTotals
+	database.DB.Model(&models.ReadingSession{}).
+		Select("COUNT(*) AS sessions, COALESCE(SUM(duration_minutes), 0) AS minutes").
+		Where("admin_id = ?", userID).
+		Scan(&allSessions)
+	overview.Sessions = allSessions.Sessions
+	overview.MinutesRead = allSessions.Minutes
+
+	byDay := make(map[string]*DailyReadingProgress, days)
+	overview.Series = make([]DailyReadingProgress, days)
+	for i := range overview.Series {
+		overview.Series[i].Date = since.AddDate(0, 0, i).Format(helpers.DateLayout)
+		byDay[overview.Series[i].Date] = &overview.Series[i]
+	}
+
+	var sessions []models.ReadingSession
+	database.DB.Where("admin_id = ? AND read_at >= ?", userID, since).Find(&sessions)
+	var timedPages int64
+	for _, session := range sessions {
+		overview.WindowPages += int64(session.PagesRead)
+		overview.WindowMinutes += int64(session.DurationMinutes)
+		if session.DurationMinutes > 0 {
+			timedPages += int64(session.PagesRead)
+		}
+		if entry, ok := byDay[session.ReadAt.In(helpers.AppLocation()).Format(helpers.DateLayout)]; ok {
+			if entry.Sessions == 0 {
+				overview.ActiveDays++
+			}
+			entry.Pages += int64(session.PagesRead)
+			entry.Minutes += int64(session.DurationMinutes)
+			entry.Sessions++
+		}
+	}
+	overview.PagesPerDay = roundOne(float64(overview.WindowPages) / float64(days))
+	if overview.WindowMinutes > 0 {
+		overview.PagesPerHour = roundOne(float64(timedPages) / float64(overview.WindowMinutes) * 60)
+	}
+
+	return c.JSON(overview)
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"backend/models"
+	"testing"
+	"time"
+)
+
+// sameTime compares optional timestamps
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestSettleProgress(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	earlier := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		progress       models.BookProgress
+		wantStatus     string
+		wantPage       int
+		wantPercentage float64
+		wantStarted    *time.Time
+		wantFinished   *time.Time
+	}{
+		{
+			"new progress starts reading",
+			models.BookProgress{TotalPages: 300, CurrentPage: 30},
+			models.ShelfReading, 30, 10, &now, nil,
+		},
+		{
+			"negative page clamped to 0",
+			models.BookProgress{Status: models.ShelfReading, TotalPages: 300, CurrentPage: -5, StartedAt: &earlier},
+			models.ShelfReading, 0, 0, &earlier, nil,
+		},
+		{
+			"percentage rounded to two decimals",
+			models.BookProgress{Status: models.ShelfReading, TotalPages: 3, CurrentPage: 1, StartedAt: &earlier},
+			models.ShelfReading, 1, 33.33, &earlier, nil,
+		},
+		{
+			"last page finishes the book",
+			models.BookProgress{Status: models.ShelfReading, TotalPages: 300, CurrentPage: 300, StartedAt: &earlier},
+			models.ShelfFinished, 300, 100, &earlier, &now,
+		},
+		{
+			"page past the end clamped and finished",
+			models.BookProgress{Status: models.ShelfReading, TotalPages: 300, CurrentPage: 350, StartedAt: &earlier},
+			models.ShelfFinished, 300, 100, &earlier, &now,
+		},
+		{
+			"finish date finishes a book without page count",
+			models.BookProgress{Status: models.ShelfReading, FinishedAt: &earlier},
+			models.ShelfFinished, 0, 0, &now, &earlier,
+		},
+		{
+			"moving back from the last page reads again",
+			models.BookProgress{Status: models.ShelfFinished, TotalPages: 300, CurrentPage: 150, StartedAt: &earlier, FinishedAt: &earlier},
+			models.ShelfReading, 150, 50, &earlier, nil,
+		},
+		{
+			"finished without page count stays finished",
+			models.BookProgress{Status: models.ShelfFinished, StartedAt: &earlier, FinishedAt: &earlier},
+			models.ShelfFinished, 0, 0, &earlier, &earlier,
+		},
+		{
+			"want to read moves to reading once a page is read",
+			models.BookProgress{Status: models.ShelfWantToRead, TotalPages: 300, CurrentPage: 1},
+			models.ShelfReading, 1, 0.33, &now, nil,
+		},
+		{
+			"want to read stays without pages",
+			models.BookProgress{Status: models.ShelfWantToRead, TotalPages: 300},
+			models.ShelfWantToRead, 0, 0, nil, nil,
+		},
+		{
+			"abandoned keeps its shelf while paging",
+			models.BookProgress{Status: models.ShelfAbandoned, TotalPages: 300, CurrentPage: 120, StartedAt: &earlier},
+			models.ShelfAbandoned, 120, 40, &earlier, nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := tt.progress
+			settleProgress(&progress, now)
+			if progress.Status != tt.wantStatus || progress.CurrentPage != tt.wantPage || progress.Percentage != tt.wantPercentage {
+				t.Errorf("settleProgress() = status %q, page %d, %v%%, want %q, %d, %v%%",
+					progress.Status, progress.CurrentPage, progress.Percentage, tt.wantStatus, tt.wantPage, tt.wantPercentage)
+			}
+			if !sameTime(progress.StartedAt, tt.wantStarted) {
+				t.Errorf("settleProgress() StartedAt = %v, want %v", progress.StartedAt, tt.wantStarted)
+			}
+			if !sameTime(progress.FinishedAt, tt.wantFinished) {
+				t.Errorf("settleProgress() FinishedAt = %v, want %v", progress.FinishedAt, tt.wantFinished)
+			}
+		})
+	}
+}
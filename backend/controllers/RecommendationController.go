@@ -0,0 +1,414 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// interactionSource is a user->poem join table used as an implicit feedback signal
+type interactionSource struct {
+	Table  string
+	Weight float64
+	Recent bool // Only rows of the last RECOMMENDATION_VIEW_WINDOW_DAYS days count
+}
+
+// interactionSources lists the signals the recommender learns from, strongest first
+var interactionSources = []interactionSource{
+	{Table: "admin_liked_poems", Weight: 1.0},
+	{Table: "admin_bookmark_poems", Weight: 0.6},
+	{Table: "poem_views", Weight: 0.25, Recent: true},
+}
+
+const (
+	similarNeighbours     = 30  // Related poems kept per poem
+	similarityShrinkage   = 3.0 // Damps scores backed by only a few users
+	maxRecommendationPool = 200 // Candidates ranked for the personalized feed
+)
+
+var recomputeMu sync.Mutex
+
+// ScoredPoem is a poem together with the score it was ranked by
+type ScoredPoem struct {
+	models.Poem
+	Score float64 `json:"score"`
+}
+
+// StartRecommendationWorker recomputes poem similarities on startup and then periodically
+func StartRecommendationWorker() {
+	interval := helpers.GetEnvDuration("RECOMMENDATION_REFRESH_INTERVAL", time.Hour)
+	for {
+		if err := computePoemSimilarities(); err != nil {
+			fmt.Printf("[Recommendations] recompute failed: %v\n", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// loadUserInteractions returns user -> poem -> weight, the sum of the signals the user gave
+// the poem. Each source counts once per pair, so daily views do not pile up over time, and
+// the aggregation runs in the database so only one row per pair is loaded. A non-zero
+// userID limits the result to that user.
+func loadUserInteractions(db *gorm.DB, userID uint) (map[uint]map[uint]float64, error) {
+	since := helpers.AppNow().AddDate(0, 0, -helpers.GetEnvInt("RECOMMENDATION_VIEW_WINDOW_DAYS", 90)).Format(helpers.DateLayout)
+
+	selects := make([]string, 0, len(interactionSources))
+	args := make([]interface{}, 0, len(interactionSources)*3)
+	for _, source := range interactionSources {
+		query := "SELECT DISTINCT admin_id, poem_id, ?::float8 AS weight FROM " + source.Table + " WHERE admin_id <> 0"
+		args = append(args, source.Weight)
+		if userID != 0 {
+			query += " AND admin_id = ?"
+			args = append(args, userID)
+		}
+		if source.Recent {
+			query += " AND day >= ?"
+			args = append(args, since)
+		}
+		selects = append(selects, query)
+	}
+
+	var rows []struct {
+		AdminID uint
+		PoemID  uint
+		Weight  float64
+	}
+	err := db.Raw(`SELECT signals.admin_id, signals.poem_id, SUM(signals.weight) AS weight
+		FROM (`+strings.Join(selects, " UNION ALL ")+`) AS signals
+		JOIN poems ON poems.id = signals.poem_id
+		WHERE poems.is_deleted = false
+		GROUP BY signals.admin_id, signals.poem_id`, args...).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[uint]map[uint]float64)
+	for _, row := range rows {
+		if users[row.AdminID] == nil {
+			users[row.AdminID] = make(map[uint]float64)
+		}
+		users[row.AdminID][row.PoemID] = row.Weight
+	}
+	return users, nil
+}
+
+// itemSimilarities scores every pair of poems read by the same users with cosine similarity,
+// shrunk towards 0 when few users back it, and keeps the best similarNeighbours per poem
+func itemSimilarities(users map[uint]map[uint]float64, computedAt time.Time) []models.PoemSimilarity {
+	type coOccurrence struct {
+		dot     float64
+		support int
+	}
+
+	norms := make(map[uint]float64)
+	pairs := make(map[uint]map[uint]*coOccurrence)
+	for _, items := range users {
+		for i, wi := range items {
+			norms[i] += wi * wi
+			for j, wj := range items {
+				if i == j {
+					continue
+				}
+				if pairs[i] == nil {
+					pairs[i] = make(map[uint]*coOccurrence)
+				}
+				pair := pairs[i][j]
+				if pair == nil {
+					pair = &coOccurrence{}
+					pairs[i][j] = pair
+				}
+				pair.dot += wi * wj
+				pair.support++
+			}
+		}
+	}
+
+	var similarities []models.PoemSimilarity
+	for poemID, related := range pairs {
+		scored := make([]models.PoemSimilarity, 0, len(related))
+		for relatedID, pair := range related {
+			cosine := pair.dot / math.Sqrt(norms[poemID]*norms[relatedID])
+			shrink := float64(pair.support) / (float64(pair.support) + similarityShrinkage)
+			scored = append(scored, models.PoemSimilarity{
+				PoemID:        poemID,
+				RelatedPoemID: relatedID,
+				Score:         cosine * shrink,
+				Support:       pair.support,
+				ComputedAt:    computedAt,
+			})
+		}
+		sort.Slice(scored, func(a, b int) bool {
+			if scored[a].Score == scored[b].Score {
+				return scored[a].RelatedPoemID < scored[b].RelatedPoemID
+			}
+			return scored[a].Score > scored[b].Score
+		})
+		if len(scored) > similarNeighbours {
+			scored = scored[:similarNeighbours]
+		}
+		similarities = append(similarities, scored...)
+	}
+	return similarities
+}
+
+// computePoemSimilarities rebuilds poem_similarities with shrunk cosine similarity
+func computePoemSimilarities() error {
+	recomputeMu.Lock()
+	defer recomputeMu.Unlock()
+
+	started := time.Now()
+	users, err := loadUserInteractions(database.DB, 0)
+	if err != nil {
+		return err
+	}
+	similarities := itemSimilarities(users, started)
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.PoemSimilarity{}).Error; err != nil {
+			return err
+		}
+		if len(similarities) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(similarities, 500).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("[Recommendations] users=%d pairs=%d took=%s\n",
+		len(users), len(similarities), time.Since(started))
+	return nil
+}
+
+// loadPoemsInOrder loads poems with author data and returns them in the order of ids
+func loadPoemsInOrder(ids []uint) []models.Poem {
+	if len(ids) == 0 {
+		return []models.Poem{}
+	}
+
+	var poems []models.Poem
+	database.DB.Preload("AuthorData").Where("id IN ?", ids).Find(&poems)
+
+	byID := make(map[uint]models.Poem, len(poems))
+	for _, poem := range poems {
+		byID[poem.ID] = poem
+	}
+
+	ordered := make([]models.Poem, 0, len(poems))
+	for _, id := range ids {
+		if poem, ok := byID[id]; ok {
+			ordered = append(ordered, poem)
+		}
+	}
+	return ordered
+}
+
+// visiblePoemIDs keeps the ids of non-deleted poems the role is allowed to see, in input order
+func visiblePoemIDs(ids []uint, roleID uint) []uint {
+	if len(ids) == 0 {
+		return ids
+	}
+
+	var allowed []uint
+	query := database.DB.Model(&models.Poem{}).Where("id IN ? AND is_deleted = ?", ids, false)
+	query = applyCommunityFilter(query, roleID)
+	query.Pluck("id", &allowed)
+
+	allowedSet := make(map[uint]bool, len(allowed))
+	for _, id := range allowed {
+		allowedSet[id] = true
+	}
+
+	visible := make([]uint, 0, len(allowed))
+	for _, id := range ids {
+		if allowedSet[id] {
+			visible = append(visible, id)
+		}
+	}
+	return visible
+}
+
+// scorePoems pairs ordered poems with their scores
+func scorePoems(ids []uint, scores map[uint]float64) []ScoredPoem {
+	poems := loadPoemsInOrder(ids)
+	result := make([]ScoredPoem, 0, len(poems))
+	for _, poem := range poems {
+		result = append(result, ScoredPoem{Poem: poem, Score: scores[poem.ID]})
+	}
+	return result
+}
+
+// GetRelatedPoems returns "readers who liked this also liked" poems for a poem slug
+func GetRelatedPoems(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "6"))
+	if limit <= 0 || limit > similarNeighbours {
+		limit = 6
+	}
+
+	var poem models.Poem
+	query := database.DB.Where("slug = ? AND is_deleted = ?", slug, false)
+	query = applyCommunityFilter(query, roleID)
+	if err := query.First(&poem).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Poem not found",
+		})
+	}
+
+	var similarities []models.PoemSimilarity
+	database.DB.Where("poem_id = ?", poem.ID).Order("score DESC").Find(&similarities)
+
+	ids := make([]uint, 0, len(similarities))
+	scores := make(map[uint]float64, len(similarities))
+	for _, similarity := range similarities {
+		ids = append(ids, similarity.RelatedPoemID)
+		scores[similarity.RelatedPoemID] = similarity.Score
+	}
+
+	ids = visiblePoemIDs(ids, roleID)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	return c.JSON(fiber.Map{
+		"poem_id": poem.ID,
+		"poems":   scorePoems(ids, scores),
+	})
+}
+
+// rankRecommendations scores the poems related to those the user interacted with, weighting
+// each similarity by the user's signal on the seed poem. Poems the user already interacted
+// with are never recommended back. At most maxRecommendationPool ids are returned, best first.
+func rankRecommendations(interacted map[uint]float64, similarities []models.PoemSimilarity) ([]uint, map[uint]float64) {
+	scores := make(map[uint]float64)
+	for _, similarity := range similarities {
+		if _, seen := interacted[similarity.RelatedPoemID]; seen {
+			continue
+		}
+		scores[similarity.RelatedPoemID] += similarity.Score * interacted[similarity.PoemID]
+	}
+
+	ranked := make([]uint, 0, len(scores))
+	for poemID := range scores {
+		ranked = append(ranked, poemID)
+	}
+	sort.Slice(ranked, func(a, b int) bool {
+		if scores[ranked[a]] == scores[ranked[b]] {
+			return ranked[a] > ranked[b]
+		}
+		return scores[ranked[a]] > scores[ranked[b]]
+	})
+	if len(ranked) > maxRecommendationPool {
+		ranked = ranked[:maxRecommendationPool]
+	}
+	return ranked, scores
+}
+
+// GetRecommendations returns a personalized poem feed for the current user.
+// Users without interactions (or without overlapping readers) get popular poems instead.
+func GetRecommendations(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+	params := helpers.GetPaginationParams(c)
+
+	// Poems the user already interacted with are never recommended back
+	interacted := make(map[uint]float64)
+	if userID != 0 {
+		users, err := loadUserInteractions(database.DB, userID)
+		if err != nil {
+			return err
+		}
+		if users[userID] != nil {
+			interacted = users[userID]
+		}
+	}
+
+	var ranked []uint
+	var scores map[uint]float64
+	if len(interacted) > 0 {
+		seedIDs := make([]uint, 0, len(interacted))
+		for poemID := range interacted {
+			seedIDs = append(seedIDs, poemID)
+		}
+
+		var similarities []models.PoemSimilarity
+		database.DB.Where("poem_id IN ?", seedIDs).Find(&similarities)
+		ranked, scores = rankRecommendations(interacted, similarities)
+	}
+	ranked = visiblePoemIDs(ranked, roleID)
+
+	source := "personalized"
+	if len(ranked) == 0 {
+		source = "popular"
+		ranked, scores = popularPoemIDs(roleID, interacted, maxRecommendationPool)
+	}
+
+	total := int64(len(ranked))
+	start := params.Offset
+	if start > len(ranked) {
+		start = len(ranked)
+	}
+	end := start + params.Limit
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+
+	response := helpers.CreatePaginationResponse(scorePoems(ranked[start:end], scores), total, params.Offset, params.Limit)
+
+	fmt.Printf("[GetRecommendations] userID=%d, roleID=%d, source=%s, total=%d\n", userID, roleID, source, total)
+
+	return c.JSON(fiber.Map{
+		"source":          source,
+		"recommendations": response,
+	})
+}
+
+// popularPoemIDs returns visible poems ordered by like count, skipping excluded ids
+func popularPoemIDs(roleID uint, exclude map[uint]float64, limit int) ([]uint, map[uint]float64) {
+	var rows []PoemWithLikes
+	query := database.DB.Table("poems").
+		Select("poems.id, COUNT(admin_liked_poems.poem_id) as like_count").
+		Joins("LEFT JOIN admin_liked_poems ON poems.id = admin_liked_poems.poem_id").
+		Where("poems.is_deleted = ?", false).
+		Group("poems.id").
+		Order("like_count DESC, poems.id DESC")
+
+	if len(exclude) > 0 {
+		excluded := make([]uint, 0, len(exclude))
+		for poemID := range exclude {
+			excluded = append(excluded, poemID)
+		}
+		query = query.Where("poems.id NOT IN ?", excluded)
+	}
+
+	query = applyCommunityFilter(query, roleID)
+	query.Limit(limit).Scan(&rows)
+
+	ids := make([]uint, 0, len(rows))
+	scores := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+		scores[row.ID] = float64(row.LikeCount)
+	}
+	return ids, scores
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"backend/models"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestItemSimilarities(t *testing.T) {
+	users := map[uint]map[uint]float64{
+		1: {10: 1, 20: 1},    // Liked both
+		2: {10: 1, 20: 1},    // Liked both
+		3: {10: 1, 30: 0.25}, // Liked 10, viewed 30
+		4: {40: 1},           // Nothing in common with anyone
+	}
+	similarities := itemSimilarities(users, time.Time{})
+
+	byPair := map[[2]uint]models.PoemSimilarity{}
+	for _, similarity := range similarities {
+		byPair[[2]uint{similarity.PoemID, similarity.RelatedPoemID}] = similarity
+	}
+	if len(byPair) != 4 {
+		t.Fatalf("itemSimilarities() = %+v, want 10-20, 20-10, 10-30 and 30-10", similarities)
+	}
+	if _, ok := byPair[[2]uint{40, 10}]; ok {
+		t.Errorf("itemSimilarities() relates poem 40 read by a single user")
+	}
+
+	// Poem 10: norm² 3; poem 20: norm² 2; both read by two users
+	want := 2 / math.Sqrt(3*2) * 2 / (2 + similarityShrinkage)
+	if got := byPair[[2]uint{10, 20}]; math.Abs(got.Score-want) > 1e-9 || got.Support != 2 {
+		t.Errorf("10-20 = score %v support %d, want %v and 2", got.Score, got.Support, want)
+	}
+	if a, b := byPair[[2]uint{10, 20}].Score, byPair[[2]uint{20, 10}].Score; math.Abs(a-b) > 1e-9 {
+		t.Errorf("similarity is not symmetric: %v and %v", a, b)
+	}
+	// Backed by one user only, so shrunk harder than 10-20
+	if byPair[[2]uint{10, 30}].Score >= byPair[[2]uint{10, 20}].Score {
+		t.Errorf("10-30 = %v, want less than 10-20 = %v", byPair[[2]uint{10, 30}].Score, byPair[[2]uint{10, 20}].Score)
+	}
+}
+
+func TestRankRecommendations(t *testing.T) {
+	interacted := map[uint]float64{1: 1, 2: 0.25}
+	similarities := []models.PoemSimilarity{
+		{PoemID: 1, RelatedPoemID: 2, Score: 0.9}, // Already read: never recommended back
+		{PoemID: 1, RelatedPoemID: 3, Score: 0.5},
+		{PoemID: 2, RelatedPoemID: 3, Score: 0.4},
+		{PoemID: 2, RelatedPoemID: 4, Score: 0.8}, // Strong, but only from a weak signal
+		{PoemID: 1, RelatedPoemID: 5, Score: 0.3},
+	}
+	ranked, scores := rankRecommendations(interacted, similarities)
+
+	if want := []uint{3, 5, 4}; !reflect.DeepEqual(ranked, want) {
+		t.Errorf("rankRecommendations() = %v, want %v", ranked, want)
+	}
+	if math.Abs(scores[3]-0.6) > 1e-9 || math.Abs(scores[4]-0.2) > 1e-9 {
+		t.Errorf("scores = %v, want 3: 0.6 and 4: 0.2", scores)
+	}
+	if _, ok := scores[2]; ok {
+		t.Errorf("rankRecommendations() scored poem 2 the user already read")
+	}
+}
@@ -0,0 +1,532 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"backend/security"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxShelvesPerUser caps how many custom shelves a user can create
+const maxShelvesPerUser = 50
+
+// ShelfSummary is a shelf with the number of books on it
+type ShelfSummary struct {
+	Status    string `json:"status"`
+	BookCount int64  `json:"book_count"`
+}
+
+// ShelvedBook is a book on a shelf together with the owner's reading state, if any
+type ShelvedBook struct {
+	models.Book
+	Progress *models.BookProgress `json:"progress"`
+}
+
+// isShelfStatus reports whether status is one of the built-in shelves
+func isShelfStatus(status string) bool {
+	for _, shelf := range models.ShelfStatuses {
+		if status == shelf {
+			return true
+		}
+	}
+	return false
+}
+
+// applyShelfStatus moves progress to a built-in shelf and keeps the finish date consistent
+func applyShelfStatus(progress *models.BookProgress, status string, now time.Time) {
+	if progress.Status == status {
+		return
+	}
+	progress.Status = status
+	progress.StatusChangedAt = &now
+
+	switch status {
+	case models.ShelfFinished:
+		if progress.FinishedAt == nil {
+			progress.FinishedAt = &now
+		}
+		if progress.TotalPages > 0 {
+			progress.CurrentPage = progress.TotalPages
+			progress.Percentage = 100
+		}
+	default:
+		progress.FinishedAt = nil
+	}
+	if progress.StartedAt == nil && status != models.ShelfWantToRead {
+		progress.StartedAt = &now
+	}
+}
+
+// saveProgress stores progress, records a shelf move when its status changed since previous,
+// keeps the legacy read list in sync with the finished shelf and tells the user's book clubs
+// when they finish the club's book
+func saveProgress(tx *gorm.DB, progress *models.BookProgress, previous string, now time.Time) error {
+	if err := tx.Save(progress).Error; err != nil {
+		return err
+	}
+	if progress.Status == previous {
+		return nil
+	}
+
+	move := models.ShelfMove{
+		AdminID:    progress.AdminID,
+		BookID:     progress.BookID,
+		Action:     models.ShelfMoveStatus,
+		FromStatus: previous,
+		ToStatus:   progress.Status,
+		CreatedAt:  now,
+	}
+	if err := tx.Create(&move).Error; err != nil {
+		return err
+	}
+
+	if progress.Status == models.ShelfFinished {
+		if err := recordClubBookFinished(tx, progress.AdminID, progress.BookID, now); err != nil {
+			return err
+		}
+		return markBookRead(tx, progress.AdminID, progress.BookID, now)
+	}
+	if previous == models.ShelfFinished {
+		return unmarkBookRead(tx, progress.AdminID, progress.BookID)
+	}
+	return nil
+}
+
+// removeFromStatusShelf takes a book off the user's built-in shelves. Logged sessions are kept.
+func removeFromStatusShelf(tx *gorm.DB, userID uint, bookID uint, now time.Time) error {
+	var progress models.BookProgress
+	if err := tx.Where("admin_id = ? AND book_id = ?", userID, bookID).First(&progress).Error; err != nil {
+		return nil
+	}
+	if err := tx.Delete(&progress).Error; err != nil {
+		return err
+	}
+	move := models.ShelfMove{
+		AdminID:    userID,
+		BookID:     bookID,
+		Action:     models.ShelfMoveStatus,
+		FromStatus: progress.Status,
+		CreatedAt:  now,
+	}
+	if err := tx.Create(&move).Error; err != nil {
+		return err
+	}
+	return unmarkBookRead(tx, userID, bookID)
+}
+
+// markBookRead keeps the legacy read list in sync when a book is finished
+func markBookRead(tx *gorm.DB, userID uint, bookID uint, now time.Time) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserBookRead{AdminID: userID, BookID: bookID, CreatedAt: &now}).Error
+}
+
+// unmarkBookRead removes a book from the legacy read list
+func unmarkBookRead(tx *gorm.DB, userID uint, bookID uint) error {
+	return tx.Where("admin_id = ? AND book_id = ?", userID, bookID).Delete(&models.UserBookRead{}).Error
+}
+
+// findOwnShelf returns one of the user's custom shelves
+func findOwnShelf(shelfID int, userID uint) (models.Shelf, error) {
+	var shelf models.Shelf
+	err := database.DB.Where("id = ? AND admin_id = ?", shelfID, userID).First(&shelf).Error
+	return shelf, err
+}
+
+// shelfSummaries counts the user's books per built-in shelf and custom shelf,
+// limited to books the viewer's role can see
+func shelfSummaries(userID uint, viewerRoleID uint) ([]ShelfSummary, []models.Shelf) {
+	var counts []ShelfSummary
+	query := database.DB.Model(&models.BookProgress{}).
+		Select("book_progresses.status, COUNT(*) AS book_count").
+		Joins("JOIN books ON books.id = book_progresses.book_id AND books.is_deleted = ?", false).
+		Where("book_progresses.admin_id = ?", userID)
+	if viewerRoleID != 1 && viewerRoleID != 2 {
+		query = query.Where("books.community = ?", 2)
+	}
+	query.Group("book_progresses.status").Scan(&counts)
+
+	countByStatus := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		countByStatus[count.Status] = count.BookCount
+	}
+	statuses := make([]ShelfSummary, 0, len(models.ShelfStatuses))
+	for _, status := range models.ShelfStatuses {
+		statuses = append(statuses, ShelfSummary{Status: status, BookCount: countByStatus[status]})
+	}
+
+	shelves := []models.Shelf{}
+	database.DB.Where("admin_id = ?", userID).Order("name ASC").Find(&shelves)
+	if len(shelves) == 0 {
+		return statuses, shelves
+	}
+
+	shelfIDs := make([]uint, 0, len(shelves))
+	for _, shelf := range shelves {
+		shelfIDs = append(shelfIDs, shelf.ID)
+	}
+	var shelfCounts []struct {
+		ShelfID   uint
+		BookCount int64
+	}
+	query = database.DB.Model(&models.ShelfBook{}).
+		Select("shelf_books.shelf_id, COUNT(*) AS book_count").
+		Joins("JOIN books ON books.id = shelf_books.book_id AND books.is_deleted = ?", false).
+		Where("shelf_books.shelf_id IN ?", shelfIDs)
+	if viewerRoleID != 1 && viewerRoleID != 2 {
+		query = query.Where("books.community = ?", 2)
+	}
+	query.Group("shelf_books.shelf_id").Scan(&shelfCounts)
+
+	countByShelf := make(map[uint]int64, len(shelfCounts))
+	for _, count := range shelfCounts {
+		countByShelf[count.ShelfID] = count.BookCount
+	}
+	for i := range shelves {
+		shelves[i].BookCount = countByShelf[shelves[i].ID]
+	}
+	return statuses, shelves
+}
+
+// parseShelfName validates a custom shelf name and builds its slug
+func parseShelfName(c *fiber.Ctx) (string, string, error) {
+	var data map[string]string
+	if err := c.BodyParser(&data); err != nil {
+		return "", "", errors.New("Geçersiz istek")
+	}
+	sanitizer := security.NewSanitizer()
+	name := strings.TrimSpace(sanitizer.SanitizePlainText(data["name"]))
+	if name == "" || len([]rune(name)) > 64 {
+		return "", "", errors.New("Raf adı 1-64 karakter olmalı")
+	}
+	slug := helpers.Slugify(name)
+	if isShelfStatus(strings.ReplaceAll(slug, "-", "_")) {
+		return "", "", errors.New("Bu raf adı kullanılamaz")
+	}
+	return name, slug, nil
+}
+
+// SetBookShelf moves a book to one of the session user's built-in shelves.
+// An empty status takes the book off its shelf.
+func SetBookShelf(c *fiber.Ctx) error {
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data map[string]string
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+	status := data["status"]
+	if status != "" && !isShelfStatus(status) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz raf",
+		})
+	}
+
+	book, err := findVisibleBook(uint(bookID), roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kitap bulunamadı",
+		})
+	}
+
+	now := helpers.AppNow()
+	var progress models.BookProgress
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if status == "" {
+			return removeFromStatusShelf(tx, userID, book.ID, now)
+		}
+		var err error
+		if progress, err = loadOrStartProgress(tx, userID, book); err != nil {
+			return err
+		}
+		previous := progress.Status
+		applyShelfStatus(&progress, status, now)
+		if previous == models.ShelfFinished && status == models.ShelfReading {
+			// Re-reading starts from the beginning
+			progress.CurrentPage = 0
+			progress.Percentage = 0
+			progress.StartedAt = &now
+		}
+		return saveProgress(tx, &progress, previous, now)
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Raf güncellenemedi",
+		})
+	}
+
+	fmt.Printf("[Shelves] userID=%d bookID=%d status=%q\n", userID, book.ID, status)
+	checkReadingMilestones(userID)
+
+	if status == "" {
+		return c.JSON(fiber.Map{
+			"message": "Kitap raftan kaldırıldı",
+		})
+	}
+	return c.JSON(withProgressStats(userID, []models.BookProgress{progress})[0])
+}
+
+// GetShelves returns the session user's built-in and custom shelves with book counts
+func GetShelves(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	statuses, shelves := shelfSummaries(userID, roleID)
+	return c.JSON(fiber.Map{
+		"statuses": statuses,
+		"shelves":  shelves,
+	})
+}
+
+// CreateShelf creates a custom shelf for the session user
+func CreateShelf(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	name, slug, err := parseShelfName(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	var count int64
+	database.DB.Model(&models.Shelf{}).Where("admin_id = ?", userID).Count(&count)
+	if count >= maxShelvesPerUser {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "En fazla 50 raf oluşturabilirsiniz",
+		})
+	}
+
+	shelf := models.Shelf{AdminID: userID, Name: name, Slug: slug}
+	if err := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&shelf).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Raf oluşturulamadı",
+		})
+	}
+	if shelf.ID == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"message": "Bu isimde bir rafınız zaten var",
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(shelf)
+}
+
+// UpdateShelf renames one of the session user's custom shelves
+func UpdateShelf(c *fiber.Ctx) error {
+	shelfID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+
+	shelf, err := findOwnShelf(shelfID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Raf bulunamadı",
+		})
+	}
+	name, slug, err := parseShelfName(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	var taken int64
+	database.DB.Model(&models.Shelf{}).Where("admin_id = ? AND slug = ? AND id <> ?", userID, slug, shelf.ID).Count(&taken)
+	if taken > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"message": "Bu isimde bir rafınız zaten var",
+		})
+	}
+
+	if err := database.DB.Model(&shelf).Updates(map[string]interface{}{"name": name, "slug": slug}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Raf güncellenemedi",
+		})
+	}
+	return c.JSON(shelf)
+}
+
+// DeleteShelf deletes one of the session user's custom shelves. The books stay on their built-in shelves.
+func DeleteShelf(c *fiber.Ctx) error {
+	shelfID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+
+	shelf, err := findOwnShelf(shelfID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Raf bulunamadı",
+		})
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shelf_id = ?", shelf.ID).Delete(&models.ShelfBook{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&shelf).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Raf silinemedi",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Raf silindi",
+	})
+}
+
+// AddBookToShelf puts a book on one of the session user's custom shelves
+func AddBookToShelf(c *fiber.Ctx) error {
+	shelfID, _ := strconv.Atoi(c.Params("id"))
+	userID := GetUserId(c)
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	var data struct {
+		BookID uint `json:"book_id"`
+	}
+	if err := c.BodyParser(&data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Geçersiz istek",
+		})
+	}
+
+	shelf, err := findOwnShelf(shelfID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Raf bulunamadı",
+		})
+	}
+	book, err := findVisibleBook(data.BookID, roleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Kitap bulunamadı",
+		})
+	}
+
+	now := helpers.AppNow()
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.ShelfBook{ShelfID: shelf.ID, BookID: book.ID, CreatedAt: now})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Create(&models.ShelfMove{
+			AdminID:   userID,
+			BookID:    book.ID,
+			Action:    models.ShelfMoveAdd,
+			ShelfID:   &shelf.ID,
+			CreatedAt: now,
+		}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Kitap rafa eklenemedi",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Kitap rafa eklendi",
+	})
+}
+
+// RemoveBookFromShelf takes a book off one of the session user's custom shelves
+func RemoveBookFromShelf(c *fiber.Ctx) error {
+	shelfID, _ := strconv.Atoi(c.Params("id"))
+	bookID, _ := strconv.Atoi(c.Params("book_id"))
+	userID := GetUserId(c)
+
+	shelf, err := findOwnShelf(shelfID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Raf bulunamadı",
+		})
+	}
+
+	now := helpers.AppNow()
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("shelf_id = ? AND book_id = ?", shelf.ID, bookID).Delete(&models.ShelfBook{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Create(&models.ShelfMove{
+			AdminID:   userID,
+			BookID:    uint(bookID),
+			Action:    models.ShelfMoveRemove,
+			ShelfID:   &shelf.ID,
+			CreatedAt: now,
+		}).Error
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Kitap raftan kaldırılamadı",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Kitap raftan kaldırıldı",
+	})
+}
+
+// GetShelfMoves returns the session user's shelf history, newest first, optionally for one book (?book_id=)
+func GetShelfMoves(c *fiber.Ctx) error {
+	userID := GetUserId(c)
+	params := helpers.GetPaginationParams(c)
+
+	query := database.DB.Model(&models.ShelfMove{}).Where("admin_id = ?", userID)
+	if bookID := c.Query("book_id"); bookID != "" {
+		query = query.Where("book_id = ?", bookID)
+	}
+
+	var total int64
+	query.Count(&total)
+
+	moves := []models.ShelfMove{}
+	query.Order("created_at DESC, id DESC").
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Find(&moves)
+
+	return c.JSON(helpers.CreatePaginationResponse(moves, total, params.Offset, params.Limit))
+}
+
+// shelvedBookOrder maps ?sort= to an ORDER BY for profile shelf listings.
+// addedColumn is when the book was put on the listed shelf.
+func shelvedBookOrder(sort string, addedColumn string) string {
+	switch sort {
+	case "oldest":
+		return addedColumn + " ASC NULLS LAST, books.id ASC"
+	case "title":
+		return "books.name ASC, books.id ASC"
+	case "author":
+		return "books.author ASC, books.name ASC"
+	case "progress":
+		return "book_progresses.percentage DESC NULLS LAST, books.id DESC"
+	case "started":
+		return "book_progresses.started_at DESC NULLS LAST, books.id DESC"
+	case "finished":
+		return "book_progresses.finished_at DESC NULLS LAST, books.id DESC"
+	}
+	return addedColumn + " DESC NULLS LAST, books.id DESC"
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"backend/models"
+	"testing"
+	"time"
+)
+
+func TestApplyShelfStatus(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	earlier := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		progress     models.BookProgress
+		status       string
+		wantPage     int
+		wantStarted  *time.Time
+		wantFinished *time.Time
+		wantChanged  bool
+	}{
+		{
+			"reading to finished jumps to the last page",
+			models.BookProgress{Status: models.ShelfReading, TotalPages: 200, CurrentPage: 80, StartedAt: &earlier},
+			models.ShelfFinished, 200, &earlier, &now, true,
+		},
+		{
+			"finished keeps a given finish date",
+			models.BookProgress{Status: models.ShelfReading, TotalPages: 200, StartedAt: &earlier, FinishedAt: &earlier},
+			models.ShelfFinished, 200, &earlier, &earlier, true,
+		},
+		{
+			"finished to reading clears the finish date",
+			models.BookProgress{Status: models.ShelfFinished, TotalPages: 200, CurrentPage: 200, StartedAt: &earlier, FinishedAt: &earlier},
+			models.ShelfReading, 200, &earlier, nil, true,
+		},
+		{
+			"abandoning starts an unstarted book",
+			models.BookProgress{Status: models.ShelfWantToRead, TotalPages: 200},
+			models.ShelfAbandoned, 0, &now, nil, true,
+		},
+		{
+			"want to read does not start the book",
+			models.BookProgress{TotalPages: 200},
+			models.ShelfWantToRead, 0, nil, nil, true,
+		},
+		{
+			"same shelf changes nothing",
+			models.BookProgress{Status: models.ShelfFinished, TotalPages: 200, CurrentPage: 150, FinishedAt: &earlier},
+			models.ShelfFinished, 150, nil, &earlier, false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := tt.progress
+			applyShelfStatus(&progress, tt.status, now)
+			if progress.Status != tt.status || progress.CurrentPage != tt.wantPage {
+				t.Errorf("applyShelfStatus() = status %q, page %d, want %q, %d", progress.Status, progress.CurrentPage, tt.status, tt.wantPage)
+			}
+			if !sameTime(progress.StartedAt, tt.wantStarted) {
+				t.Errorf("applyShelfStatus() StartedAt = %v, want %v", progress.StartedAt, tt.wantStarted)
+			}
+			if !sameTime(progress.FinishedAt, tt.wantFinished) {
+				t.Errorf("applyShelfStatus() FinishedAt = %v, want %v", progress.FinishedAt, tt.wantFinished)
+			}
+			if changed := progress.StatusChangedAt != nil; changed != tt.wantChanged {
+				t.Errorf("applyShelfStatus() StatusChangedAt = %v, want set %v", progress.StatusChangedAt, tt.wantChanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"backend/models"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	poemContentIndex     = helpers.NewTextIndex()
+	poemContentIndexOnce sync.Once
+)
+
+// contentIndex returns the poem content index, building it from the database on first use
+func contentIndex() *helpers.TextIndex {
+	poemContentIndexOnce.Do(func() {
+		var poems []models.Poem
+		database.DB.Select("id", "title", "content").Where("is_deleted = ?", false).Find(&poems)
+		for _, poem := range poems {
+			poemContentIndex.Upsert(poem.ID, poemTerms(poem))
+		}
+		fmt.Printf("[ContentIndex] indexed %d poems\n", poemContentIndex.Len())
+	})
+	return poemContentIndex
+}
+
+// poemTerms analyzes a poem for the content index; the title counts twice
+func poemTerms(poem models.Poem) []string {
+	terms := helpers.AnalyzeTurkish(poem.Title)
+	terms = append(terms, terms...)
+	return append(terms, helpers.AnalyzeTurkish(poem.Content)...)
+}
+
+// indexPoemContent keeps the content index in sync after a poem is created, updated or deleted
+func indexPoemContent(poemID uint) {
+	var poem models.Poem
+	if err := database.DB.Select("id", "title", "content", "is_deleted").First(&poem, poemID).Error; err != nil || poem.IsDeleted {
+		contentIndex().Remove(poemID)
+		return
+	}
+	contentIndex().Upsert(poem.ID, poemTerms(poem))
+}
+
+// GetSimilarPoems returns poems similar in theme and wording to the given poem
+func GetSimilarPoems(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "6"))
+	if limit <= 0 || limit > 30 {
+		limit = 6
+	}
+
+	var poem models.Poem
+	query := database.DB.Where("slug = ? AND is_deleted = ?", slug, false)
+	query = applyCommunityFilter(query, roleID)
+	if err := query.First(&poem).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Poem not found",
+		})
+	}
+
+	// Over-fetch so hidden poems can be filtered out without running short
+	matches := contentIndex().Similar(poem.ID, limit*3, 0.05)
+	ids := make([]uint, 0, len(matches))
+	scores := make(map[uint]float64, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+		scores[match.ID] = match.Score
+	}
+
+	ids = visiblePoemIDs(ids, roleID)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	return c.JSON(fiber.Map{
+		"poem_id": poem.ID,
+		"poems":   scorePoems(ids, scores),
+	})
+}
+
+// NearDuplicatePoem is a short poem reference used in the duplicate report
+type NearDuplicatePoem struct {
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Author    string `json:"author"`
+	AuthorID  *uint  `json:"author_id"`
+	Community int    `json:"community"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GetNearDuplicatePoems lists poem pairs whose content is nearly identical (admin only)
+func GetNearDuplicatePoems(c *fiber.Ctx) error {
+	threshold, err := strconv.ParseFloat(c.Query("threshold", "0.85"), 64)
+	if err != nil || threshold <= 0 || threshold > 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "threshold must be between 0 and 1",
+		})
+	}
+	params := helpers.GetPaginationParams(c)
+
+	pairs := contentIndex().Pairs(threshold)
+	total := int64(len(pairs))
+
+	start := params.Offset
+	if start > len(pairs) {
+		start = len(pairs)
+	}
+	end := start + params.Limit
+	if end > len(pairs) {
+		end = len(pairs)
+	}
+	pairs = pairs[start:end]
+
+	ids := make([]uint, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		ids = append(ids, pair.AID, pair.BID)
+	}
+
+	var poems []NearDuplicatePoem
+	if len(ids) > 0 {
+		database.DB.Model(&models.Poem{}).
+			Select("id, title, slug, author, author_id, community, created_at").
+			Where("id IN ?", ids).
+			Scan(&poems)
+	}
+	byID := make(map[uint]NearDuplicatePoem, len(poems))
+	for _, poem := range poems {
+		byID[poem.ID] = poem
+	}
+
+	type duplicatePair struct {
+		PoemA NearDuplicatePoem `json:"poem_a"`
+		PoemB NearDuplicatePoem `json:"poem_b"`
+		Score float64           `json:"score"`
+	}
+	result := make([]duplicatePair, 0, len(pairs))
+	for _, pair := range pairs {
+		result = append(result, duplicatePair{
+			PoemA: byID[pair.AID],
+			PoemB: byID[pair.BID],
+			Score: pair.Score,
+		})
+	}
+
+	return c.JSON(helpers.CreatePaginationResponse(result, total, params.Offset, params.Limit))
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/helpers"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// trendingWindows are the look-back windows accepted by GetTrendingPoems
+var trendingWindows = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// TrendingPoem is a poem with its time-decayed trending score
+type TrendingPoem struct {
+	PoemWithLikes
+	TrendingScore float64 `json:"trending_score"`
+}
+
+// resolveTrendingWindow returns the look-back window for ?window=, falling back to
+// TRENDING_DEFAULT_WINDOW and then to a week. ok is false for an unknown window.
+func resolveTrendingWindow(requested string) (name string, window time.Duration, ok bool) {
+	name = requested
+	if name == "" {
+		name = os.Getenv("TRENDING_DEFAULT_WINDOW")
+	}
+	if name == "" {
+		name = "week"
+	}
+	window, ok = trendingWindows[name]
+	return name, window, ok
+}
+
+// GetTrendingPoems ranks poems by recent likes and bookmarks with Hacker News style decay:
+// each interaction contributes weight / (age_in_hours + 2) ^ gravity.
+func GetTrendingPoems(c *fiber.Ctx) error {
+	roleID, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	windowName, window, ok := resolveTrendingWindow(c.Query("window"))
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "window must be one of: day, week, month",
+		})
+	}
+	gravity := helpers.GetEnvFloat("TRENDING_GRAVITY", 1.8)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit := 10
+	offset := (page - 1) * limit
+	since := time.Now().Add(-window)
+
+	communityCondition := ""
+	if communityLevelForRole(roleID) == communityLevelPublic {
+		communityCondition = "AND poems.community = 2"
+	}
+
+	events := `SELECT poem_id, created_at, 1.0 AS weight FROM admin_liked_poems WHERE created_at >= @since
+		UNION ALL
+		SELECT poem_id, created_at, 0.5 AS weight FROM admin_bookmark_poems WHERE created_at >= @since`
+
+	var poems []TrendingPoem
+	database.DB.Raw(`WITH events AS (`+events+`),
+		scored AS (
+			SELECT poem_id, SUM(weight / POWER(EXTRACT(EPOCH FROM (NOW() - created_at)) / 3600.0 + 2, @gravity)) AS trending_score
+			FROM events
+			GROUP BY poem_id
+		)
+		SELECT poems.*, scored.trending_score,
+			(SELECT COUNT(*) FROM admin_liked_poems WHERE admin_liked_poems.poem_id = poems.id) AS like_count
+		FROM scored
+		JOIN poems ON poems.id = scored.poem_id
+		WHERE poems.is_deleted = false `+communityCondition+`
+		ORDER BY scored.trending_score DESC, poems.id DESC
+		OFFSET @offset LIMIT @limit`,
+		map[string]interface{}{"since": since, "gravity": gravity, "offset": offset, "limit": limit},
+	).Scan(&poems)
+
+	listed := make([]PoemWithLikes, len(poems))
+	for i := range poems {
+		listed[i] = poems[i].PoemWithLikes
+	}
+	attachPoemListData(listed, GetUserId(c), roleID)
+	for i := range poems {
+		poems[i].PoemWithLikes = listed[i]
+	}
+
+	var total int64
+	database.DB.Raw(`WITH events AS (`+events+`)
+		SELECT COUNT(DISTINCT events.poem_id)
+		FROM events
+		JOIN poems ON poems.id = events.poem_id
+		WHERE poems.is_deleted = false `+communityCondition,
+		map[string]interface{}{"since": since},
+	).Scan(&total)
+
+	fmt.Printf("[GetTrendingPoems] roleID=%d, window=%s, gravity=%.2f, total=%d\n", roleID, windowName, gravity, total)
+
+	return c.JSON(fiber.Map{
+		"poems":  poems,
+		"window": windowName,
+		"meta": fiber.Map{
+			"total":     total,
+			"page":      page,
+			"last_page": divideAndRoundUp(int(total), limit),
+		},
+	})
+}
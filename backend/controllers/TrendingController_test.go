@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveTrendingWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultWindow string
+		requested     string
+		wantName      string
+		wantWindow    time.Duration
+		wantOK        bool
+	}{
+		{"built-in default", "", "", "week", 7 * 24 * time.Hour, true},
+		{"configured default", "day", "", "day", 24 * time.Hour, true},
+		{"requested wins", "day", "month", "month", 30 * 24 * time.Hour, true},
+		{"unknown window", "", "year", "year", 0, false},
+		{"unknown configured default", "fortnight", "", "fortnight", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRENDING_DEFAULT_WINDOW", tt.defaultWindow)
+			name, window, ok := resolveTrendingWindow(tt.requested)
+			if name != tt.wantName || window != tt.wantWindow || ok != tt.wantOK {
+				t.Errorf("resolveTrendingWindow(%q) = %q, %v, %v, want %q, %v, %v",
+					tt.requested, name, window, ok, tt.wantName, tt.wantWindow, tt.wantOK)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"backend/models"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 	"strconv"
 )
 
@@ -37,6 +38,22 @@ func AddBookToReads(c *fiber.Ctx) error {
 
 	database.DB.Save(&user)
 
+	// The read list is the finished shelf
+	var stored models.Book
+	if database.DB.First(&stored, book.ID).Error == nil {
+		now := helpers.AppNow()
+		database.DB.Transaction(func(tx *gorm.DB) error {
+			progress, err := loadOrStartProgress(tx, user.ID, stored)
+			if err != nil {
+				return err
+			}
+			previous := progress.Status
+			applyShelfStatus(&progress, models.ShelfFinished, now)
+			return saveProgress(tx, &progress, previous, now)
+		})
+	}
+	checkReadingMilestones(user.ID)
+
 	return c.JSON(user)
 }
 func DeleteBookFromReads(c *fiber.Ctx) error {
@@ -66,6 +83,17 @@ func DeleteBookFromReads(c *fiber.Ctx) error {
 
 	database.DB.Save(&user)
 
+	// Taking a book off the read list takes it off the finished shelf
+	var finished int64
+	database.DB.Model(&models.BookProgress{}).
+		Where("admin_id = ? AND book_id = ? AND status = ?", user.ID, book.ID, models.ShelfFinished).
+		Count(&finished)
+	if finished > 0 {
+		database.DB.Transaction(func(tx *gorm.DB) error {
+			return removeFromStatusShelf(tx, user.ID, book.ID, helpers.AppNow())
+		})
+	}
+
 	return c.JSON(user)
 }
 
@@ -123,6 +151,7 @@ func GetReadsBooksPaginated(c *fiber.Ctx) error {
 		searchPattern := "%" + search + "%"
 		query = query.Where("name ILIKE ? OR author ILIKE ?", searchPattern, searchPattern)
 	}
+	query = applyBookMetadataFilters(query, c)
 
 	// Count total matching books
 	var total int64
@@ -137,23 +166,16 @@ func GetReadsBooksPaginated(c *fiber.Ctx) error {
 		searchPattern := "%" + search + "%"
 		query = query.Where("name ILIKE ? OR author ILIKE ?", searchPattern, searchPattern)
 	}
+	query = applyBookMetadataFilters(query, c)
 
-	// Preload comments with Admin data and AuthorData
-	query = query.Preload("Comments", "is_deleted = ?", false).
-		Preload("Comments.Admin").
-		Preload("AuthorData")
+	query = preloadBookMetadata(query.Preload("AuthorData"))
 
 	query.Offset(params.Offset).
 		Limit(params.Limit).
 		Find(&books)
 
-	// Filter comments by friendship for each book
-	for i := range books {
-		books[i].Comments = filterCommentsByFriendship(books[i].Comments, userID, roleID)
-		if books[i].Comments == nil {
-			books[i].Comments = []models.Comment{}
-		}
-	}
+	// Load comment threads visible to the user for all books at once
+	attachBookCommentThreads(books, userID, roleID)
 
 	// Create paginated response
 	response := helpers.CreatePaginationResponse(books, total, params.Offset, params.Limit)
@@ -199,6 +221,7 @@ func GetNotReadsBooksPaginated(c *fiber.Ctx) error {
 		searchPattern := "%" + search + "%"
 		baseQuery = baseQuery.Where("name ILIKE ? OR author ILIKE ?", searchPattern, searchPattern)
 	}
+	baseQuery = applyBookMetadataFilters(baseQuery, c)
 
 	// Count total unread books
 	var total int64
@@ -217,24 +240,17 @@ func GetNotReadsBooksPaginated(c *fiber.Ctx) error {
 		searchPattern := "%" + search + "%"
 		query = query.Where("name ILIKE ? OR author ILIKE ?", searchPattern, searchPattern)
 	}
+	query = applyBookMetadataFilters(query, c)
 
-	// Preload comments with Admin data and AuthorData
-	query = query.Preload("Comments", "is_deleted = ?", false).
-		Preload("Comments.Admin").
-		Preload("AuthorData")
+	query = preloadBookMetadata(query.Preload("AuthorData"))
 
 	query.Offset(params.Offset).
 		Limit(params.Limit).
 		Order("created_at DESC").
 		Find(&books)
 
-	// Filter comments by friendship for each book
-	for i := range books {
-		books[i].Comments = filterCommentsByFriendship(books[i].Comments, userID, roleID)
-		if books[i].Comments == nil {
-			books[i].Comments = []models.Comment{}
-		}
-	}
+	// Load comment threads visible to the user for all books at once
+	attachBookCommentThreads(books, userID, roleID)
 
 	// Create paginated response
 	response := helpers.CreatePaginationResponse(books, total, params.Offset, params.Limit)
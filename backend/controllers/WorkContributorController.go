@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"backend/database"
+	"backend/models"
+	"backend/security"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Subqueries selecting the poems and books with a contributor whose name matches, for
+// "id IN (...)" search clauses
+const (
+	poemContributorSearchSQL = `SELECT work_contributors.poem_id FROM work_contributors
+	JOIN authors ON authors.id = work_contributors.author_id
+	WHERE work_contributors.poem_id IS NOT NULL AND authors.is_deleted = false AND authors.name ILIKE ?`
+	bookContributorSearchSQL = `SELECT work_contributors.book_id FROM work_contributors
+	JOIN authors ON authors.id = work_contributors.author_id
+	WHERE work_contributors.book_id IS NOT NULL AND authors.is_deleted = false AND authors.name ILIKE ?`
+)
+
+func isContributorRole(role string) bool {
+	for _, known := range models.ContributorRoles {
+		if role == known {
+			return true
+		}
+	}
+	return false
+}
+
+// numberContributors validates the roles of the contributors sent with a poem or book and
+// numbers them in request order within each role. Position 0 of the author role belongs to
+// the primary author (the content's author_id), so it is skipped here and never sent twice.
+func numberContributors(primary *uint, input []models.WorkContributor) ([]models.WorkContributor, error) {
+	contributors := []models.WorkContributor{}
+	seen := map[string]bool{}
+	positions := map[string]int{models.ContributorRoleAuthor: 1}
+	for _, item := range input {
+		if !isContributorRole(item.Role) {
+			return nil, &security.ValidationError{Field: "contributors", Message: fmt.Sprintf("unknown role %q", item.Role)}
+		}
+		if item.Role == models.ContributorRoleAuthor && primary != nil && item.AuthorID == *primary {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", item.Role, item.AuthorID)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		contributors = append(contributors, models.WorkContributor{
+			AuthorID: item.AuthorID,
+			Role:     item.Role,
+			Position: positions[item.Role],
+		})
+		positions[item.Role]++
+	}
+	return contributors, nil
+}
+
+// resolveContributors numbers the contributors sent with a poem or book and checks that
+// their authors exist
+func resolveContributors(tx *gorm.DB, primary *uint, input []models.WorkContributor) ([]models.WorkContributor, error) {
+	contributors, err := numberContributors(primary, input)
+	if err != nil {
+		return nil, err
+	}
+	for _, contributor := range contributors {
+		var count int64
+		if err := tx.Model(&models.Author{}).Where("id = ? AND is_deleted = ?", contributor.AuthorID, false).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, &security.ValidationError{Field: "contributors", Message: fmt.Sprintf("author %d not found", contributor.AuthorID)}
+		}
+	}
+	return contributors, nil
+}
+
+// contributorOwner points a contributor at the poem or book identified by column and id
+func contributorOwner(contributor *models.WorkContributor, column string, id uint) {
+	if column == "poem_id" {
+		contributor.PoemID = &id
+	} else {
+		contributor.BookID = &id
+	}
+}
+
+// syncPrimaryContributor makes the primary author contributor of a poem or book (column is
+// poem_id or book_id) match its author_id
+func syncPrimaryContributor(tx *gorm.DB, column string, id uint, primary *uint) error {
+	query := tx.Where(column+" = ? AND role = ?", id, models.ContributorRoleAuthor)
+	if primary != nil {
+		query = query.Where("(position = 0 OR author_id = ?)", *primary)
+	} else {
+		query = query.Where("position = 0")
+	}
+	if err := query.Delete(&models.WorkContributor{}).Error; err != nil {
+		return err
+	}
+	if primary == nil {
+		return nil
+	}
+	contributor := models.WorkContributor{AuthorID: *primary, Role: models.ContributorRoleAuthor}
+	contributorOwner(&contributor, column, id)
+	return tx.Create(&contributor).Error
+}
+
+// replaceContributors sets the full contributor list of a poem or book: the primary author
+// followed by the resolved input
+func replaceContributors(tx *gorm.DB, column string, id uint, primary *uint, input []models.WorkContributor) error {
+	contributors, err := resolveContributors(tx, primary, input)
+	if err != nil {
+		return err
+	}
+	if err := tx.Where(column+" = ?", id).Delete(&models.WorkContributor{}).Error; err != nil {
+		return err
+	}
+	if err := syncPrimaryContributor(tx, column, id, primary); err != nil {
+		return err
+	}
+	for i := range contributors {
+		contributorOwner(&contributors[i], column, id)
+	}
+	if len(contributors) == 0 {
+		return nil
+	}
+	return tx.Create(&contributors).Error
+}
+
+// preloadContributors loads the contributors of poems or books with their authors, in
+// role and position order
+func preloadContributors(query *gorm.DB) *gorm.DB {
+	return query.Preload("Contributors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("role, position, id")
+	}).Preload("Contributors.Author")
+}
+
+// authorWorkRow is one contribution of an author as scanned by loadAuthorWorksByRole
+type authorWorkRow struct {
+	Role     string
+	Position int
+	ID       uint
+	Title    string
+	Slug     string
+}
+
+// groupAuthorWorks adds the scanned poems or books (workType) to works under their role
+func groupAuthorWorks(works map[string][]models.AuthorWork, workType string, rows []authorWorkRow) {
+	for _, row := range rows {
+		works[row.Role] = append(works[row.Role], models.AuthorWork{
+			Type: workType, ID: row.ID, Title: row.Title, Slug: row.Slug, Position: row.Position,
+		})
+	}
+}
+
+// loadAuthorWorksByRole lists the poems and books an author contributed to that the
+// community level can see, grouped by role
+func loadAuthorWorksByRole(authorID uint, level int) map[string][]models.AuthorWork {
+	works := map[string][]models.AuthorWork{}
+
+	var poems []authorWorkRow
+	applyCommunityLevelFilter(database.DB.Table("work_contributors").
+		Select("work_contributors.role, work_contributors.position, poems.id, poems.title, poems.slug").
+		Joins("JOIN poems ON poems.id = work_contributors.poem_id"), level).
+		Where("work_contributors.author_id = ? AND poems.is_deleted = ?", authorID, false).
+		Order("poems.title").
+		Scan(&poems)
+	groupAuthorWorks(works, "poem", poems)
+
+	var books []authorWorkRow
+	applyCommunityLevelFilter(database.DB.Table("work_contributors").
+		Select("work_contributors.role, work_contributors.position, books.id, books.name AS title, books.slug").
+		Joins("JOIN books ON books.id = work_contributors.book_id"), level).
+		Where("work_contributors.author_id = ? AND books.is_deleted = ?", authorID, false).
+		Order("books.name").
+		Scan(&books)
+	groupAuthorWorks(works, "book", books)
+	return works
+}
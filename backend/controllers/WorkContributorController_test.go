@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"backend/models"
+	"backend/security"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNumberContributors(t *testing.T) {
+	primary := uint(1)
+	contributor := func(authorID uint, role string, position int) models.WorkContributor {
+		return models.WorkContributor{AuthorID: authorID, Role: role, Position: position}
+	}
+
+	tests := []struct {
+		name    string
+		primary *uint
+		input   []models.WorkContributor
+		want    []models.WorkContributor
+	}{
+		{"empty", &primary, nil, []models.WorkContributor{}},
+		{
+			"numbered per role in request order",
+			&primary,
+			[]models.WorkContributor{
+				contributor(5, models.ContributorRoleTranslator, 9),
+				contributor(2, models.ContributorRoleAuthor, 0),
+				contributor(6, models.ContributorRoleTranslator, 0),
+				contributor(3, models.ContributorRoleAuthor, 0),
+			},
+			[]models.WorkContributor{
+				contributor(5, models.ContributorRoleTranslator, 0),
+				contributor(2, models.ContributorRoleAuthor, 1),
+				contributor(6, models.ContributorRoleTranslator, 1),
+				contributor(3, models.ContributorRoleAuthor, 2),
+			},
+		},
+		{
+			"primary author skipped",
+			&primary,
+			[]models.WorkContributor{
+				contributor(1, models.ContributorRoleAuthor, 0),
+				contributor(2, models.ContributorRoleAuthor, 0),
+			},
+			[]models.WorkContributor{contributor(2, models.ContributorRoleAuthor, 1)},
+		},
+		{
+			"primary author kept in other roles",
+			&primary,
+			[]models.WorkContributor{contributor(1, models.ContributorRoleTranslator, 0)},
+			[]models.WorkContributor{contributor(1, models.ContributorRoleTranslator, 0)},
+		},
+		{
+			"without a primary author the author role still starts at 1",
+			nil,
+			[]models.WorkContributor{contributor(1, models.ContributorRoleAuthor, 0)},
+			[]models.WorkContributor{contributor(1, models.ContributorRoleAuthor, 1)},
+		},
+		{
+			"repeated role and author dropped",
+			&primary,
+			[]models.WorkContributor{
+				contributor(4, models.ContributorRoleEditor, 0),
+				contributor(4, models.ContributorRoleEditor, 0),
+				contributor(4, models.ContributorRoleIllustrator, 0),
+			},
+			[]models.WorkContributor{
+				contributor(4, models.ContributorRoleEditor, 0),
+				contributor(4, models.ContributorRoleIllustrator, 0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := numberContributors(tt.primary, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("numberContributors() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := numberContributors(&primary, []models.WorkContributor{contributor(2, "narrator", 0)})
+	var validationErr *security.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("numberContributors() with an unknown role error = %v, want a ValidationError", err)
+	}
+}
+
+func TestRepeatedContributionIDs(t *testing.T) {
+	poem, otherPoem, book := uint(10), uint(11), uint(10)
+	contributions := []models.WorkContributor{
+		{ID: 1, PoemID: &poem, Role: models.ContributorRoleAuthor, Position: 2},
+		{ID: 2, PoemID: &poem, Role: models.ContributorRoleAuthor, Position: 0}, // Kept: lowest position
+		{ID: 3, PoemID: &poem, Role: models.ContributorRoleTranslator, Position: 1},
+		{ID: 4, PoemID: &poem, Role: models.ContributorRoleTranslator, Position: 1}, // Same position: higher id goes
+		{ID: 5, PoemID: &otherPoem, Role: models.ContributorRoleAuthor, Position: 1},
+		{ID: 6, BookID: &book, Role: models.ContributorRoleAuthor, Position: 1}, // Book 10 is not poem 10
+	}
+	got := repeatedContributionIDs(contributions)
+	if want := []uint{4, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("repeatedContributionIDs() = %v, want %v", got, want)
+	}
+	if got := repeatedContributionIDs(nil); len(got) != 0 {
+		t.Errorf("repeatedContributionIDs(nil) = %v, want none", got)
+	}
+}
+
+func TestGroupAuthorWorks(t *testing.T) {
+	works := map[string][]models.AuthorWork{}
+	groupAuthorWorks(works, "poem", []authorWorkRow{
+		{Role: models.ContributorRoleAuthor, Position: 0, ID: 1, Title: "Kuşlar", Slug: "kuslar"},
+		{Role: models.ContributorRoleTranslator, Position: 1, ID: 2, Title: "Deniz", Slug: "deniz"},
+	})
+	groupAuthorWorks(works, "book", []authorWorkRow{
+		{Role: models.ContributorRoleAuthor, Position: 1, ID: 1, Title: "Seçme Şiirler", Slug: "secme-siirler"},
+	})
+
+	want := map[string][]models.AuthorWork{
+		models.ContributorRoleAuthor: {
+			{Type: "poem", ID: 1, Title: "Kuşlar", Slug: "kuslar", Position: 0},
+			{Type: "book", ID: 1, Title: "Seçme Şiirler", Slug: "secme-siirler", Position: 1},
+		},
+		models.ContributorRoleTranslator: {
+			{Type: "poem", ID: 2, Title: "Deniz", Slug: "deniz", Position: 1},
+		},
+	}
+	if !reflect.DeepEqual(works, want) {
+		t.Errorf("groupAuthorWorks() = %+v, want %+v", works, want)
+	}
+}
@@ -43,6 +43,10 @@ func ConnectDb() {
 
 	DB = db
 
+	if err := setupJoinTables(db); err != nil {
+		panic("Could not set up join tables")
+	}
+
 	err = db.AutoMigrate(
 		&models.Poem{},
 		&models.Admin{},
@@ -53,10 +57,51 @@ func ConnectDb() {
 		&models.Homepage{},
 		&models.MihrimahCard{},
 		&models.Friendship{},
+		&models.PoemOfTheDay{},
+		&models.PoemSimilarity{},
+		&models.AdminLikedPoem{},
+		&models.AdminBookmarkPoem{},
+		&models.UserBookRead{},
+		&models.PoemView{},
+		&models.CommentReaction{},
+		&models.CommentRevision{},
+		&models.Report{},
+		&models.UserStrike{},
+		&models.CommentMention{},
+		&models.BookProgress{},
+		&models.ReadingSession{},
+		&models.Shelf{},
+		&models.ShelfBook{},
+		&models.ShelfMove{},
+		&models.BookReview{},
+		&models.ReadingGoal{},
+		&models.ReadingChallenge{},
+		&models.ReadingChallengeMember{},
+		&models.Work{},
+		&models.Genre{},
+		&models.Series{},
+		&models.Tag{},
+		&models.BookClub{},
+		&models.BookClubMember{},
+		&models.BookClubSegment{},
+		&models.BookClubPost{},
+		&models.BookClubActivity{},
+		&models.AuthorFollow{},
+		&models.Notification{},
+		&models.AuthorSlugRedirect{},
+		&models.LiteraryPeriod{},
+		&models.OnThisDayPost{},
+		&models.WorkContributor{},
 	)
 	if err != nil {
 		panic("Could not migrate to the database")
 	} else {
 		println("Migrated to the database")
 	}
+
+	backfillRelationTimestamps(db)
+	migrateReadBooksToShelves(db)
+	backfillBookWorks(db)
+	seedLiteraryPeriods(db)
+	backfillWorkContributors(db)
 }
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"backend/models"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// setupJoinTables replaces the implicit many2many tables with join models carrying created_at.
+// It must run before AutoMigrate and before any association is used.
+func setupJoinTables(db *gorm.DB) error {
+	joinTables := []struct {
+		field string
+		model interface{}
+	}{
+		{"AdminLikedPoems", &models.AdminLikedPoem{}},
+		{"AdminBookmarkPoems", &models.AdminBookmarkPoem{}},
+		{"UserBooksRead", &models.UserBookRead{}},
+	}
+	for _, joinTable := range joinTables {
+		if err := db.SetupJoinTable(&models.Admin{}, joinTable.field, joinTable.model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillRelationTimestamps dates like, bookmark and read rows that predate created_at.
+// The content's creation date is the earliest the interaction could have happened, so
+// legacy rows are dated there and age out of trending instead of all looking brand new.
+func backfillRelationTimestamps(db *gorm.DB) {
+	statements := []string{
+		`UPDATE admin_liked_poems AS r
+			SET created_at = CASE WHEN p.created_at ~ '^\d{2}-\d{2}-\d{4}$' THEN to_timestamp(p.created_at, 'DD-MM-YYYY') ELSE NOW() END
+			FROM poems p
+			WHERE p.id = r.poem_id AND r.created_at IS NULL`,
+		`UPDATE admin_bookmark_poems AS r
+			SET created_at = CASE WHEN p.created_at ~ '^\d{2}-\d{2}-\d{4}$' THEN to_timestamp(p.created_at, 'DD-MM-YYYY') ELSE NOW() END
+			FROM poems p
+			WHERE p.id = r.poem_id AND r.created_at IS NULL`,
+		`UPDATE user_books_read AS r
+			SET created_at = CASE WHEN b.created_at ~ '^\d{2}-\d{2}-\d{4}$' THEN to_timestamp(b.created_at, 'DD-MM-YYYY') ELSE NOW() END
+			FROM books b
+			WHERE b.id = r.book_id AND r.created_at IS NULL`,
+		// Rows pointing at missing content
+		`UPDATE admin_liked_poems SET created_at = NOW() WHERE created_at IS NULL`,
+		`UPDATE admin_bookmark_poems SET created_at = NOW() WHERE created_at IS NULL`,
+		`UPDATE user_books_read SET created_at = NOW() WHERE created_at IS NULL`,
+	}
+
+	for _, statement := range statements {
+		result := db.Exec(statement)
+		if result.Error != nil {
+			fmt.Printf("[Migration] relation timestamp backfill failed: %v\n", result.Error)
+			return
+		}
+		if result.RowsAffected > 0 {
+			fmt.Printf("[Migration] backfilled created_at on %d relation rows\n", result.RowsAffected)
+		}
+	}
+}
+
+// migrateReadBooksToShelves puts every book in the legacy read list on the finished shelf
+// and fixes the status of progress rows created before shelves existed. Both steps only
+// touch rows that are still out of date, so they are safe to run on every start.
+func migrateReadBooksToShelves(db *gorm.DB) {
+	statements := []string{
+		`INSERT INTO book_progresses (admin_id, book_id, status, status_changed_at, current_page, total_pages, percentage, finished_at, created_at, updated_at)
+			SELECT r.admin_id, r.book_id, 'finished', COALESCE(r.created_at, NOW()), b.page, b.page, 100, COALESCE(r.created_at, NOW()), NOW(), NOW()
+			FROM user_books_read r
+			JOIN books b ON b.id = r.book_id
+			ON CONFLICT (admin_id, book_id) DO NOTHING`,
+		`UPDATE book_progresses SET status = 'finished' WHERE finished_at IS NOT NULL AND status <> 'finished'`,
+		`UPDATE book_progresses SET status_changed_at = COALESCE(finished_at, started_at, created_at) WHERE status_changed_at IS NULL`,
+	}
+
+	for _, statement := range statements {
+		result := db.Exec(statement)
+		if result.Error != nil {
+			fmt.Printf("[Migration] shelf migration failed: %v\n", result.Error)
+			return
+		}
+		if result.RowsAffected > 0 {
+			fmt.Printf("[Migration] moved %d rows to reading shelves\n", result.RowsAffected)
+		}
+	}
+}
+
+// backfillBookWorks gives every book without a work its own work, so each existing book
+// starts out as the only edition of a work. Editions can then be grouped by pointing them
+// at the same work_id.
+func backfillBookWorks(db *gorm.DB) {
+	result := db.Exec(`DO $$
+		DECLARE
+			book RECORD;
+			new_work_id BIGINT;
+		BEGIN
+			FOR book IN SELECT id, name, author_id, language, publication_year FROM books WHERE work_id IS NULL LOOP
+				INSERT INTO works (title, author_id, original_language, first_published_year, created_at)
+					VALUES (book.name, book.author_id, COALESCE(book.language, ''), book.publication_year, NOW())
+					RETURNING id INTO new_work_id;
+				UPDATE books SET work_id = new_work_id WHERE id = book.id;
+			END LOOP;
+		END $$`)
+	if result.Error != nil {
+		fmt.Printf("[Migration] work backfill failed: %v\n", result.Error)
+	}
+}
+
+// literaryPeriodSeeds are the eras and movements Turkish poetry is usually browsed by
+var literaryPeriodSeeds = []struct {
+	name, slug, kind string
+	start, end       int // 0 end: ongoing
+	description      string
+}{
+	{"Divan Edebiyatı", "divan-edebiyati", models.LiteraryPeriodEra, 1300, 1860,
+		"Arap ve Fars edebiyatlarının etkisinde, aruz ölçüsüyle yazılan, saray ve medrese çevresinde gelişen klasik edebiyat."},
+	{"Halk Edebiyatı", "halk-edebiyati", models.LiteraryPeriodEra, 1300, 0,
+		"Hece ölçüsü ve sade dille yazılan; âşık, tekke ve anonim halk şiirini kapsayan gelenek."},
+	{"Tanzimat Edebiyatı", "tanzimat-edebiyati", models.LiteraryPeriodEra, 1860, 1896,
+		"Şinasi'nin Tercüman-ı Ahval'iyle başlayan, Batı edebiyatı türlerini ve toplumsal konuları getiren dönem."},
+	{"Servet-i Fünun", "servet-i-funun", models.LiteraryPeriodMovement, 1896, 1901,
+		"Edebiyat-ı Cedide olarak da bilinen, \"sanat için sanat\" anlayışıyla ağır bir dil ve bireysel temalar işleyen topluluk."},
+	{"Fecr-i Âti", "fecr-i-ati", models.LiteraryPeriodMovement, 1909, 1912,
+		"\"Sanat şahsi ve muhteremdir\" ilkesiyle Servet-i Fünun çizgisini sürdüren topluluk."},
+	{"Milli Edebiyat", "milli-edebiyat", models.LiteraryPeriodEra, 1911, 1923,
+		"Genç Kalemler dergisiyle başlayan, sade Türkçe, hece ölçüsü ve milli konuları savunan dönem."},
+	{"Beş Hececiler", "bes-hececiler", models.LiteraryPeriodMovement, 1914, 1930,
+		"Hece ölçüsünü şiirde yerleştiren beş şair: Faruk Nafiz, Enis Behiç, Halit Fahri, Orhan Seyfi ve Yusuf Ziya."},
+	{"Cumhuriyet Dönemi", "cumhuriyet-donemi", models.LiteraryPeriodEra, 1923, 0,
+		"Cumhuriyetin ilanından bugüne uzanan, birçok akım ve topluluğu barındıran dönem."},
+	{"Garip", "garip", models.LiteraryPeriodMovement, 1941, 1950,
+		"Orhan Veli, Oktay Rifat ve Melih Cevdet'in ölçü, uyak ve şairaneliği reddeden Birinci Yeni hareketi."},
+	{"Hisarcılar", "hisarcilar", models.LiteraryPeriodMovement, 1950, 1960,
+		"Hisar dergisi çevresinde geleneğe bağlı, ölçülü ve milli değerlere dayalı şiiri savunan topluluk."},
+	{"İkinci Yeni", "ikinci-yeni", models.LiteraryPeriodMovement, 1954, 1965,
+		"Anlamı zorlayan imgeler ve soyut bir dille Garip'e tepki olarak doğan şiir hareketi."},
+	{"Toplumcu Gerçekçilik", "toplumcu-gercekcilik", models.LiteraryPeriodMovement, 1929, 0,
+		"Nazım Hikmet'le başlayan, şiiri toplumsal mücadelenin aracı olarak gören anlayış."},
+}
+
+// seedLiteraryPeriods adds the standard eras and movements on first start. Once periods
+// exist they are curated by admins, so renamed or deleted seeds are not brought back.
+func seedLiteraryPeriods(db *gorm.DB) {
+	var count int64
+	db.Model(&models.LiteraryPeriod{}).Count(&count)
+	if count > 0 {
+		return
+	}
+	for _, seed := range literaryPeriodSeeds {
+		start := seed.start
+		period := models.LiteraryPeriod{
+			Name:        seed.name,
+			Slug:        seed.slug,
+			Kind:        seed.kind,
+			StartYear:   &start,
+			Description: seed.description,
+		}
+		if seed.end != 0 {
+			end := seed.end
+			period.EndYear = &end
+		}
+		if err := db.Create(&period).Error; err != nil {
+			fmt.Printf("[Migration] seeding literary period %s failed: %v\n", seed.slug, err)
+		}
+	}
+}
+
+// SyncPrimaryContributors makes the position 0 author contributor of every poem and book
+// match its author_id: stale rows are removed and missing ones inserted. It runs at start
+// and after bulk changes to author_id, such as imports, legacy matching and merges.
+func SyncPrimaryContributors(db *gorm.DB) error {
+	statements := []string{
+		`DELETE FROM work_contributors wc USING poems p
+			WHERE wc.poem_id = p.id AND wc.role = 'author' AND wc.position = 0
+			AND (p.author_id IS NULL OR wc.author_id <> p.author_id)`,
+		`DELETE FROM work_contributors wc USING books b
+			WHERE wc.book_id = b.id AND wc.role = 'author' AND wc.position = 0
+			AND (b.author_id IS NULL OR wc.author_id <> b.author_id)`,
+		`INSERT INTO work_contributors (poem_id, author_id, role, position, created_at)
+			SELECT p.id, p.author_id, 'author', 0, NOW()
+			FROM poems p
+			WHERE p.author_id IS NOT NULL AND NOT EXISTS (
+				SELECT 1 FROM work_contributors wc WHERE wc.poem_id = p.id AND wc.role = 'author' AND wc.position = 0
+			)`,
+		`INSERT INTO work_contributors (book_id, author_id, role, position, created_at)
+			SELECT b.id, b.author_id, 'author', 0, NOW()
+			FROM books b
+			WHERE b.author_id IS NOT NULL AND NOT EXISTS (
+				SELECT 1 FROM work_contributors wc WHERE wc.book_id = b.id AND wc.role = 'author' AND wc.position = 0
+			)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillWorkContributors keeps each poem's and book's existing author_id as its primary
+// author contributor
+func backfillWorkContributors(db *gorm.DB) {
+	if err := SyncPrimaryContributors(db); err != nil {
+		fmt.Printf("[Migration] contributor backfill failed: %v\n", err)
+	}
+}
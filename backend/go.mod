@@ -11,6 +11,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 	golang.org/x/crypto v0.35.0
+	golang.org/x/net v0.35.0
 	gorm.io/driver/postgres v1.5.7
 	gorm.io/gorm v1.25.10
 )
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// GetEnvInt reads an integer environment variable, falling back to def when unset or invalid
+func GetEnvInt(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// GetEnvFloat reads a float environment variable, falling back to def when unset or invalid
+func GetEnvFloat(key string, def float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// GetEnvDuration reads a duration environment variable such as "15m" or "1h"
+func GetEnvDuration(key string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"regexp"
+	"unicode/utf16"
+)
+
+// mentionPattern matches @username where the username follows the registration rules
+// (letters, digits, underscore, hyphen; 3-50 chars) and the @ does not follow a word character
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_\-@])(@([A-Za-z0-9][A-Za-z0-9_\-]{2,49}))`)
+
+// Mention is an @username found in text. Start and End are UTF-16 code unit offsets
+// of the whole "@username" span, matching JavaScript string indices.
+type Mention struct {
+	Username string
+	Start    int
+	End      int
+}
+
+// FindMentions returns the @username mentions in text in order of appearance
+func FindMentions(text string) []Mention {
+	var mentions []Mention
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+		mentions = append(mentions, Mention{
+			Username: text[match[4]:match[5]],
+			Start:    utf16Length(text[:start]),
+			End:      utf16Length(text[:end]),
+		})
+	}
+	return mentions
+}
+
+// utf16Length returns the length of s in UTF-16 code units
+func utf16Length(s string) int {
+	length := 0
+	for _, char := range s {
+		length += utf16.RuneLen(char)
+	}
+	return length
+}
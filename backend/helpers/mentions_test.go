@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Mention
+	}{
+		{"none", "merhaba dünya", nil},
+		{"at start", "@ali selam", []Mention{{"ali", 0, 4}}},
+		{"after space", "selam @veli_1", []Mention{{"veli_1", 6, 13}}},
+		{"several", "@ali ve @ayşe-değil @can-k", []Mention{{"ali", 0, 4}, {"can-k", 20, 26}}},
+		{"adjacent punctuation", "(@ali), @veli.", []Mention{{"ali", 1, 5}, {"veli", 8, 13}}},
+		{"email is not a mention", "mail@example.com", nil},
+		{"double at", "@@ali", nil},
+		{"too short", "@ab", nil},
+		{"starts with underscore", "@_ali", nil},
+		// Offsets are UTF-16 code units: "ş" is one unit, the emoji two
+		{"after Turkish letters", "şiir @ali", []Mention{{"ali", 5, 9}}},
+		{"after emoji", "🌹 @ali", []Mention{{"ali", 3, 7}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindMentions(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
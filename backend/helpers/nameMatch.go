@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"sort"
+	"strings"
+)
+
+// Levenshtein returns the edit distance between two strings, counted in runes
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// sortedNameTokens normalizes a name and sorts its words, so "Emre, Yunus" and "Yunus Emre" compare equal
+func sortedNameTokens(value string) string {
+	tokens := Tokenize(FoldTurkish(value))
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// NameSimilarity scores how alike two person names are, from 0 to 1. Names are compared after
+// Turkish folding, both as written and with their words sorted, so casing, diacritics,
+// punctuation and "Surname, Name" order do not count as differences.
+func NameSimilarity(a, b string) float64 {
+	best := 0.0
+	for _, pair := range [][2]string{
+		{NormalizeName(a), NormalizeName(b)},
+		{sortedNameTokens(a), sortedNameTokens(b)},
+	} {
+		longest := max(len([]rune(pair[0])), len([]rune(pair[1])))
+		if longest == 0 {
+			continue
+		}
+		score := 1 - float64(Levenshtein(pair[0], pair[1]))/float64(longest)
+		if score > best {
+			best = score
+		}
+	}
+	return best
+}
@@ -0,0 +1,83 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	_ "time/tzdata" // Embed zoneinfo so the timezone resolves on minimal images
+)
+
+// DefaultTimezone is the timezone set on the database in scripts/init-db.sql
+const DefaultTimezone = "Europe/Istanbul"
+
+// DateLayout is the layout used for calendar dates stored as strings
+const DateLayout = "2006-01-02"
+
+var (
+	appLocation     *time.Location
+	appLocationOnce sync.Once
+)
+
+// AppLocation returns the application timezone (DB_TIMEZONE, defaults to Europe/Istanbul)
+func AppLocation() *time.Location {
+	appLocationOnce.Do(func() {
+		name := os.Getenv("DB_TIMEZONE")
+		if name == "" {
+			name = DefaultTimezone
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			fmt.Printf("[Schedule] Unknown timezone %q, falling back to %s\n", name, DefaultTimezone)
+			loc, _ = time.LoadLocation(DefaultTimezone)
+		}
+		appLocation = loc
+	})
+	return appLocation
+}
+
+// AppNow returns the current time in the application timezone
+func AppNow() time.Time {
+	return time.Now().In(AppLocation())
+}
+
+// AppToday returns today's date (YYYY-MM-DD) in the application timezone
+func AppToday() string {
+	return AppNow().Format(DateLayout)
+}
+
+// ParseAppDate parses a YYYY-MM-DD date in the application timezone
+func ParseAppDate(value string) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, value, AppLocation())
+}
+
+// RunDaily runs job once immediately and then every day at hour:minute in the
+// application timezone. It blocks, so start it with `go`.
+func RunDaily(name string, hour, minute int, job func()) {
+	runJob := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Printf("[Schedule] %s panicked: %v\n", name, r)
+			}
+		}()
+		job()
+	}
+
+	runJob()
+	for {
+		next := nextDailyRun(AppNow(), hour, minute)
+		fmt.Printf("[Schedule] %s next run at %s\n", name, next.Format(time.RFC3339))
+		time.Sleep(time.Until(next))
+		runJob()
+	}
+}
+
+// nextDailyRun returns the first hour:minute strictly after now, on the wall clock of
+// now's location
+func nextDailyRun(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,72 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDailyRun(t *testing.T) {
+	istanbul, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(year int, month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, istanbul)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"before the run on the same day", at(2026, 10, 19, 0, 4, 59), at(2026, 10, 19, 0, 5, 0)},
+		{"exactly at the run", at(2026, 10, 19, 0, 5, 0), at(2026, 10, 20, 0, 5, 0)},
+		{"late evening", at(2026, 10, 19, 23, 59, 0), at(2026, 10, 20, 0, 5, 0)},
+		// 21:30 UTC is already 00:30 of the next day in Istanbul (UTC+3)
+		{"after midnight in Istanbul but not in UTC", time.Date(2026, 10, 19, 21, 30, 0, 0, time.UTC).In(istanbul), at(2026, 10, 21, 0, 5, 0)},
+		{"before midnight in Istanbul", time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC).In(istanbul), at(2026, 10, 20, 0, 5, 0)},
+		{"year rollover", at(2026, 12, 31, 23, 0, 0), at(2027, 1, 1, 0, 5, 0)},
+		{"leap day", at(2028, 2, 28, 12, 0, 0), at(2028, 2, 29, 0, 5, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextDailyRun(tt.now, 0, 5)
+			if !got.Equal(tt.want) {
+				t.Errorf("nextDailyRun(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+			if got.Location() != istanbul {
+				t.Errorf("nextDailyRun(%s) location = %s, want %s", tt.now, got.Location(), istanbul)
+			}
+		})
+	}
+}
+
+func TestParseAppDate(t *testing.T) {
+	t.Setenv("DB_TIMEZONE", "")
+
+	tests := []struct {
+		value string
+		want  time.Time // The start of the day in Istanbul, in UTC
+	}{
+		{"2026-10-19", time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC)},
+		{"2028-02-29", time.Date(2028, 2, 28, 21, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseAppDate(tt.value)
+		if err != nil {
+			t.Fatalf("ParseAppDate(%q) error = %v", tt.value, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseAppDate(%q) = %s, want %s", tt.value, got.UTC(), tt.want)
+		}
+		if got.Format(DateLayout) != tt.value {
+			t.Errorf("ParseAppDate(%q) formats back as %q", tt.value, got.Format(DateLayout))
+		}
+	}
+
+	for _, value := range []string{"19-10-2026", "2026-02-30", "2026-10-19T00:00:00Z", ""} {
+		if _, err := ParseAppDate(value); err == nil {
+			t.Errorf("ParseAppDate(%q) succeeded, want an error", value)
+		}
+	}
+}
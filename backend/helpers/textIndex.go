@@ -0,0 +1,231 @@
+package helpers
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// TextMatch is a document scored against another document
+type TextMatch struct {
+	ID    uint    `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// TextPair is a pair of similar documents (AID < BID)
+type TextPair struct {
+	AID   uint    `json:"a_id"`
+	BID   uint    `json:"b_id"`
+	Score float64 `json:"score"`
+}
+
+// TextIndex is an in-memory TF-IDF index supporting incremental updates.
+// Term frequencies are stored raw; IDF weights are applied at query time so
+// adding or removing a document never requires rebuilding the others.
+type TextIndex struct {
+	mu       sync.RWMutex
+	docs     map[uint]map[string]int
+	postings map[string]map[uint]bool
+}
+
+// NewTextIndex creates an empty index
+func NewTextIndex() *TextIndex {
+	return &TextIndex{
+		docs:     make(map[uint]map[string]int),
+		postings: make(map[string]map[uint]bool),
+	}
+}
+
+// Upsert (re)indexes a document from its analyzed terms
+func (ix *TextIndex) Upsert(id uint, terms []string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.removeLocked(id)
+	if len(terms) == 0 {
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, term := range terms {
+		counts[term]++
+	}
+	ix.docs[id] = counts
+	for term := range counts {
+		if ix.postings[term] == nil {
+			ix.postings[term] = make(map[uint]bool)
+		}
+		ix.postings[term][id] = true
+	}
+}
+
+// Remove drops a document from the index
+func (ix *TextIndex) Remove(id uint) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.removeLocked(id)
+}
+
+func (ix *TextIndex) removeLocked(id uint) {
+	counts, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	for term := range counts {
+		delete(ix.postings[term], id)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+		}
+	}
+	delete(ix.docs, id)
+}
+
+// Len returns the number of indexed documents
+func (ix *TextIndex) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// idf uses smoothed inverse document frequency so terms in every document still count a little
+func (ix *TextIndex) idf(term string) float64 {
+	n := float64(len(ix.docs))
+	return math.Log((n+1)/(float64(len(ix.postings[term]))+1)) + 1
+}
+
+// vector returns the L2-normalized TF-IDF vector of a document
+func (ix *TextIndex) vector(id uint) map[string]float64 {
+	counts := ix.docs[id]
+	vector := make(map[string]float64, len(counts))
+	var norm float64
+	for term, count := range counts {
+		weight := (1 + math.Log(float64(count))) * ix.idf(term)
+		vector[term] = weight
+		norm += weight * weight
+	}
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		return vector
+	}
+	for term := range vector {
+		vector[term] /= norm
+	}
+	return vector
+}
+
+// Similar returns the documents most similar to id, best first
+func (ix *TextIndex) Similar(id uint, limit int, minScore float64) []TextMatch {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	if _, ok := ix.docs[id]; !ok {
+		return []TextMatch{}
+	}
+
+	source := ix.vector(id)
+	vectors := make(map[uint]map[string]float64)
+	scores := make(map[uint]float64)
+	for term, weight := range source {
+		for other := range ix.postings[term] {
+			if other == id {
+				continue
+			}
+			if vectors[other] == nil {
+				vectors[other] = ix.vector(other)
+			}
+			scores[other] += weight * vectors[other][term]
+		}
+	}
+
+	matches := make([]TextMatch, 0, len(scores))
+	for other, score := range scores {
+		if score >= minScore {
+			matches = append(matches, TextMatch{ID: other, Score: score})
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Score == matches[b].Score {
+			return matches[a].ID < matches[b].ID
+		}
+		return matches[a].Score > matches[b].Score
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// pairCandidateTerms is how many of a document's highest-weighted terms Pairs follows to
+// find candidates. Similar documents share their rare, heavy terms, so terms found in
+// most documents are never walked and Pairs does not compare every document with every other.
+const pairCandidateTerms = 10
+
+// topTerms returns up to limit terms of a vector, heaviest first
+func topTerms(vector map[string]float64, limit int) []string {
+	terms := make([]string, 0, len(vector))
+	for term := range vector {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(a, b int) bool {
+		if vector[terms[a]] == vector[terms[b]] {
+			return terms[a] < terms[b]
+		}
+		return vector[terms[a]] > vector[terms[b]]
+	})
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
+
+// dot returns the dot product of two vectors
+func dot(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var sum float64
+	for term, weight := range a {
+		sum += weight * b[term]
+	}
+	return sum
+}
+
+// Pairs returns the pairs of documents with cosine similarity >= minScore, best first.
+// Only documents sharing one of their pairCandidateTerms heaviest terms are compared.
+func (ix *TextIndex) Pairs(minScore float64) []TextPair {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	vectors := make(map[uint]map[string]float64, len(ix.docs))
+	for id := range ix.docs {
+		vectors[id] = ix.vector(id)
+	}
+
+	var pairs []TextPair
+	compared := make(map[[2]uint]bool)
+	for id, vector := range vectors {
+		for _, term := range topTerms(vector, pairCandidateTerms) {
+			for other := range ix.postings[term] {
+				key := [2]uint{min(id, other), max(id, other)}
+				if other == id || compared[key] {
+					continue
+				}
+				compared[key] = true
+				if score := dot(vector, vectors[other]); score >= minScore {
+					pairs = append(pairs, TextPair{AID: key[0], BID: key[1], Score: score})
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a].Score == pairs[b].Score {
+			if pairs[a].AID == pairs[b].AID {
+				return pairs[a].BID < pairs[b].BID
+			}
+			return pairs[a].AID < pairs[b].AID
+		}
+		return pairs[a].Score > pairs[b].Score
+	})
+	return pairs
+}
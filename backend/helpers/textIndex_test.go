@@ -0,0 +1,128 @@
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func newTestTextIndex() *TextIndex {
+	ix := NewTextIndex()
+	ix.Upsert(1, []string{"gul", "bulbul", "bahar", "bahar"})
+	ix.Upsert(2, []string{"gul", "bulbul", "bahar", "bahar"}) // Same as 1
+	ix.Upsert(3, []string{"gul", "deniz", "ruzgar"})
+	ix.Upsert(4, []string{"tren", "istasyon"}) // Nothing in common
+	return ix
+}
+
+func TestTextIndexSimilar(t *testing.T) {
+	ix := newTestTextIndex()
+
+	matches := ix.Similar(1, 0, 0)
+	if len(matches) != 2 {
+		t.Fatalf("Similar(1) = %+v, want documents 2 and 3", matches)
+	}
+	if matches[0].ID != 2 || math.Abs(matches[0].Score-1) > 1e-9 {
+		t.Errorf("best match = %+v, want document 2 with score 1", matches[0])
+	}
+	if matches[1].ID != 3 || matches[1].Score <= 0 || matches[1].Score >= matches[0].Score {
+		t.Errorf("second match = %+v, want document 3 with a lower positive score", matches[1])
+	}
+
+	tests := []struct {
+		name     string
+		id       uint
+		limit    int
+		minScore float64
+		wantIDs  []uint
+	}{
+		{"limit", 1, 1, 0, []uint{2}},
+		{"min score", 1, 0, 0.99, []uint{2}},
+		{"no shared terms", 4, 0, 0, nil},
+		{"unknown document", 99, 0, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ix.Similar(tt.id, tt.limit, tt.minScore)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Similar(%d, %d, %v) = %+v, want ids %v", tt.id, tt.limit, tt.minScore, got, tt.wantIDs)
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].ID != id {
+					t.Errorf("Similar(%d)[%d] = %d, want %d", tt.id, i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestTextIndexUpsertAndRemove(t *testing.T) {
+	ix := newTestTextIndex()
+
+	// Re-indexing replaces the old terms
+	ix.Upsert(2, []string{"tren", "istasyon"})
+	if got := ix.Similar(4, 0, 0.99); len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("after Upsert, Similar(4) = %+v, want document 2", got)
+	}
+	for _, match := range ix.Similar(1, 0, 0) {
+		if match.ID == 2 {
+			t.Errorf("after Upsert, Similar(1) still returns document 2")
+		}
+	}
+
+	ix.Remove(2)
+	if ix.Len() != 3 {
+		t.Errorf("Len() = %d after Remove, want 3", ix.Len())
+	}
+	if got := ix.Similar(4, 0, 0); len(got) != 0 {
+		t.Errorf("after Remove, Similar(4) = %+v, want none", got)
+	}
+
+	// A document without terms is not indexed
+	ix.Upsert(5, nil)
+	if ix.Len() != 3 {
+		t.Errorf("Len() = %d after empty Upsert, want 3", ix.Len())
+	}
+}
+
+func TestTextIndexPairs(t *testing.T) {
+	ix := newTestTextIndex()
+
+	pairs := ix.Pairs(0)
+	if len(pairs) != 3 {
+		t.Fatalf("Pairs(0) = %+v, want 1-2, 1-3 and 2-3", pairs)
+	}
+	if pairs[0].AID != 1 || pairs[0].BID != 2 {
+		t.Errorf("best pair = %+v, want 1-2", pairs[0])
+	}
+	for _, pair := range pairs {
+		if pair.AID >= pair.BID {
+			t.Errorf("pair %+v is not ordered by id", pair)
+		}
+	}
+
+	if got := ix.Pairs(0.99); len(got) != 1 {
+		t.Errorf("Pairs(0.99) = %+v, want only the identical documents", got)
+	}
+}
+
+func TestTextIndexPairsSkipsCommonTerms(t *testing.T) {
+	ix := NewTextIndex()
+	common := []string{"gonul", "ask", "gece", "yol", "kalp", "goz", "su", "dag", "ay", "gun", "el", "ses"}
+	for id := uint(1); id <= 40; id++ {
+		terms := append([]string{fmt.Sprintf("ozel%d", id), fmt.Sprintf("nadir%d", id)}, common...)
+		ix.Upsert(id, terms)
+	}
+	// A near-duplicate shares the rare terms of document 7 and more common words than it has candidate terms
+	ix.Upsert(41, append([]string{"ozel7", "nadir7"}, common...))
+
+	pairs := ix.Pairs(0.99)
+	if len(pairs) != 1 || pairs[0].AID != 7 || pairs[0].BID != 41 {
+		t.Errorf("Pairs(0.99) = %+v, want only 7-41", pairs)
+	}
+	for _, pair := range ix.Pairs(0) {
+		if pair.AID >= pair.BID {
+			t.Errorf("pair %+v is not ordered by id", pair)
+		}
+	}
+}
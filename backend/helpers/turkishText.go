@@ -0,0 +1,171 @@
+package helpers
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// turkishFold maps Turkish and circumflex letters to their ASCII base letter
+var turkishFold = map[rune]rune{
+	'ç': 'c', 'ğ': 'g', 'ı': 'i', 'ö': 'o', 'ş': 's', 'ü': 'u',
+	'â': 'a', 'î': 'i', 'û': 'u',
+}
+
+// slugReplacements is the character mapping used for slugs across the app
+var slugReplacements = map[rune]rune{
+	' ':  '-',
+	'ç':  'c',
+	'ğ':  'g',
+	'ı':  'i',
+	'ö':  'o',
+	'ş':  's',
+	'ü':  'u',
+	'Ç':  'C',
+	'Ğ':  'G',
+	'İ':  'I',
+	'Ö':  'O',
+	'Ş':  'S',
+	'Ü':  'U',
+	'â':  'a',
+	'Â':  'A',
+	'\'': '-',
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Slugify builds a slug the same way CreatePoem/CreateBook/CreateAuthor do
+func Slugify(value string) string {
+	var result strings.Builder
+	for _, char := range value {
+		if replacement, ok := slugReplacements[char]; ok {
+			result.WriteRune(replacement)
+		} else {
+			result.WriteRune(char)
+		}
+	}
+	return strings.ToLower(result.String())
+}
+
+// TurkishLower lowercases using Turkish casing rules (I -> ı, İ -> i)
+func TurkishLower(value string) string {
+	return strings.ToLowerSpecial(unicode.TurkishCase, value)
+}
+
+// FoldTurkish lowercases and strips Turkish diacritics so "Şükrü" and "sukru" compare equal
+func FoldTurkish(value string) string {
+	var result strings.Builder
+	for _, char := range TurkishLower(value) {
+		if folded, ok := turkishFold[char]; ok {
+			result.WriteRune(folded)
+		} else {
+			result.WriteRune(char)
+		}
+	}
+	return result.String()
+}
+
+// NormalizeName folds a person or title name and collapses punctuation and whitespace
+func NormalizeName(value string) string {
+	return strings.Join(Tokenize(FoldTurkish(value)), " ")
+}
+
+// StripHTML removes tags (keeping word boundaries) and unescapes entities
+func StripHTML(value string) string {
+	return html.UnescapeString(htmlTagPattern.ReplaceAllString(value, " "))
+}
+
+// TruncateRunes shortens value to at most limit characters without splitting a multi-byte letter
+func TruncateRunes(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	if runes := []rune(value); len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return value
+}
+
+// Tokenize splits text into letter/digit runs
+func Tokenize(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// turkishStopwords are common function words, stored in folded form
+var turkishStopwords = map[string]bool{
+	"acaba": true, "ama": true, "ancak": true, "artik": true, "aslinda": true, "az": true,
+	"bana": true, "bazi": true, "belki": true, "ben": true, "beni": true, "benim": true,
+	"beri": true, "bile": true, "bir": true, "biraz": true, "birkac": true, "biz": true,
+	"bize": true, "bizi": true, "bizim": true, "bu": true, "buna": true, "bunda": true,
+	"bundan": true, "bunu": true, "bunun": true, "burada": true, "da": true, "daha": true,
+	"de": true, "defa": true, "diye": true, "dolayi": true, "en": true, "gibi": true,
+	"hem": true, "hep": true, "hepsi": true, "her": true, "hic": true, "icin": true,
+	"ile": true, "ise": true, "iste": true, "kadar": true, "ki": true, "kim": true,
+	"mi": true, "mu": true, "nasil": true, "ne": true, "neden": true, "nerede": true,
+	"nicin": true, "o": true, "ona": true, "onda": true, "ondan": true, "onlar": true,
+	"onu": true, "onun": true, "oysa": true, "sana": true, "sen": true, "seni": true,
+	"senin": true, "siz": true, "sizi": true, "sizin": true, "su": true, "sey": true,
+	"ve": true, "veya": true, "ya": true, "yani": true,
+	// English function words show up in translated poems and notes
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"but": true, "by": true, "for": true, "from": true, "in": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "with": true,
+}
+
+// turkishSuffixes are inflectional suffixes (folded form), longest first
+var turkishSuffixes = []string{
+	"lerinden", "larindan", "lerinde", "larinda", "lerine", "larina", "lerini", "larini",
+	"lerin", "larin", "leri", "lari", "ler", "lar",
+	"imiz", "umuz", "iniz", "unuz", "miz", "muz", "niz", "nuz",
+	"ndan", "nden", "nda", "nde", "dan", "den", "tan", "ten",
+	"nin", "nun", "yla", "yle", "daki", "deki",
+	"im", "um", "in", "un", "yi", "yu", "ya", "ye", "si", "su",
+	"da", "de", "ta", "te", "la", "le",
+}
+
+const minStemLength = 3
+
+// StemTurkish strips common inflectional suffixes from a folded token.
+// It is a light stemmer: good enough to group "gözlerinde" with "göz", not a morphological analyser.
+func StemTurkish(token string) string {
+	for pass := 0; pass < 2; pass++ {
+		stripped := false
+		for _, suffix := range turkishSuffixes {
+			if strings.HasSuffix(token, suffix) && len([]rune(token))-len([]rune(suffix)) >= minStemLength {
+				token = strings.TrimSuffix(token, suffix)
+				stripped = true
+				break
+			}
+		}
+		if !stripped {
+			break
+		}
+	}
+	return token
+}
+
+// AnalyzeTurkish turns HTML or plain text into stemmed, stopword-free terms
+func AnalyzeTurkish(text string) []string {
+	tokens := Tokenize(FoldTurkish(StripHTML(text)))
+	terms := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if len([]rune(token)) < 2 || turkishStopwords[token] || isNumeric(token) {
+			continue
+		}
+		terms = append(terms, StemTurkish(token))
+	}
+	return terms
+}
+
+func isNumeric(token string) bool {
+	for _, char := range token {
+		if !unicode.IsDigit(char) {
+			return false
+		}
+	}
+	return true
+}
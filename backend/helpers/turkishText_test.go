@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		limit int
+		want  string
+	}{
+		{"short", "şiir", 10, "şiir"},
+		{"exact", "şiir", 4, "şiir"},
+		{"ascii", "merhaba", 3, "mer"},
+		// "ş" is two bytes; a byte cut at 1 would split it
+		{"multi-byte letter at the cut", "aşk", 2, "aş"},
+		{"multi-byte letters only", "çğıöşü", 3, "çğı"},
+		{"report details cut through ş", strings.Repeat("a", 999) + "şş", 1000, strings.Repeat("a", 999) + "ş"},
+		{"empty", "", 5, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateRunes(tt.value, tt.limit)
+			if got != tt.want {
+				t.Errorf("TruncateRunes(%q, %d) = %q, want %q", tt.value, tt.limit, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateRunes(%q, %d) = %q is not valid UTF-8", tt.value, tt.limit, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"backend/controllers"
 	"backend/database"
 	"backend/middlewares"
 	"backend/routes"
@@ -31,6 +32,18 @@ func main() {
 	// Start WebSocket hub
 	go ws.GlobalHub.Run()
 
+	// Pick the poem of the day for each community level after midnight
+	go controllers.StartPoemOfTheDayScheduler()
+
+	// Publish literary anniversaries to the homepage or Mihrimah cards (ON_THIS_DAY_TARGET)
+	go controllers.StartOnThisDayScheduler()
+
+	// Periodically rebuild "readers who liked this also liked" similarities
+	go controllers.StartRecommendationWorker()
+
+	// Batch-write buffered poem views
+	go controllers.StartPoemViewRecorder()
+
 	// Apply security headers middleware (FIRST - before any other middleware)
 	app.Use(middlewares.SecurityHeaders())
 
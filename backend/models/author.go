@@ -1,18 +1,27 @@
 package models
 
 type Author struct {
-	ID          uint   `json:"id" gorm:"primaryKey"`
-	Name        string `json:"name" gorm:"not null"`
-	Bio         string `json:"bio" gorm:"type:text"`
-	BirthYear   *int   `json:"birth_year"`
-	DeathYear   *int   `json:"death_year"`
-	Nationality string `json:"nationality"`
-	Image       string `json:"image"`
-	Slug        string `json:"slug" gorm:"unique;not null"`
-	IsDeleted   bool   `json:"is_deleted" gorm:"default:false"`
-	CreatedAt   string `json:"created_at"`
+	ID          uint    `json:"id" gorm:"primaryKey"`
+	Name        string  `json:"name" gorm:"not null"`
+	Bio         string  `json:"bio" gorm:"type:text"`
+	BirthYear   *int    `json:"birth_year"`
+	DeathYear   *int    `json:"death_year"`
+	BirthDate   *string `json:"birth_date" gorm:"type:varchar(10)"` // YYYY-MM-DD when the full date is known
+	DeathDate   *string `json:"death_date" gorm:"type:varchar(10)"`
+	Nationality string  `json:"nationality"`
+	Image       string  `json:"image"`
+	Slug        string  `json:"slug" gorm:"unique;not null"`
+	IsDeleted   bool    `json:"is_deleted" gorm:"default:false"`
+	CreatedAt   string  `json:"created_at"`
 
 	// Relationships
-	Poems []Poem `json:"poems,omitempty" gorm:"foreignKey:AuthorID"`
-	Books []Book `json:"books,omitempty" gorm:"foreignKey:AuthorID"`
+	Poems   []Poem           `json:"poems,omitempty" gorm:"foreignKey:AuthorID"`
+	Books   []Book           `json:"books,omitempty" gorm:"foreignKey:AuthorID"`
+	Periods []LiteraryPeriod `json:"periods,omitempty" gorm:"many2many:author_periods"`
+
+	FollowerCount int64 `json:"follower_count" gorm:"-"`
+	IsFollowing   bool  `json:"is_following" gorm:"-"` // Whether the viewer follows the author
+
+	// Poems and books the author contributed to, grouped by role
+	WorksByRole map[string][]AuthorWork `json:"works_by_role,omitempty" gorm:"-"`
 }
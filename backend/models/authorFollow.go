@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// AuthorFollow is a user's subscription to an author's new poems and books
+type AuthorFollow struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	AdminID   uint      `json:"admin_id" gorm:"not null;uniqueIndex:idx_author_follow"`
+	AuthorID  uint      `json:"author_id" gorm:"not null;uniqueIndex:idx_author_follow;index"`
+	Author    *Author   `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// AuthorSlugRedirect keeps an author slug that no longer belongs to a live author (after a
+// merge or rename) pointing at the author that replaced it
+type AuthorSlugRedirect struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex;not null"`
+	AuthorID  uint      `json:"author_id" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -12,7 +12,38 @@ type Book struct {
 	CreatedAt string `json:"created_at"`
 	Community int    `json:"community" gorm:"default:1"` // 1=private (role_id 1,2), 2=public (all)
 
+	// Edition metadata. ISBNs are stored normalized (digits only, X check character).
+	ISBN10          string   `json:"isbn10" gorm:"type:varchar(10);index"`
+	ISBN13          string   `json:"isbn13" gorm:"type:varchar(13);index"`
+	Publisher       string   `json:"publisher" gorm:"type:varchar(255);index"`
+	PublicationYear *int     `json:"publication_year" gorm:"index"`
+	Language        string   `json:"language" gorm:"type:varchar(8);index"` // ISO 639-1, e.g. "tr"
+	Translator      string   `json:"translator" gorm:"type:varchar(255)"`
+	WorkID          *uint    `json:"work_id" gorm:"index"` // Editions of the same work share it
+	SeriesID        *uint    `json:"series_id" gorm:"index"`
+	SeriesPosition  *float64 `json:"series_position" gorm:"type:numeric(6,2)"` // 1, 2, 2.5 for in-between novellas
+
+	// First publication of the text in any edition, YYYY-MM-DD; used for anniversaries
+	FirstPublishedDate *string `json:"first_published_date" gorm:"type:varchar(10)"`
+
+	// Rating aggregates, recalculated whenever a review changes. Half stars count
+	// towards the whole star below them (4.5 is in Rating4Count).
+	RatingCount   int     `json:"rating_count" gorm:"not null;default:0"`
+	RatingAverage float64 `json:"rating_average" gorm:"type:numeric(3,2);not null;default:0;index"`
+	Rating1Count  int     `json:"rating_1_count" gorm:"not null;default:0"`
+	Rating2Count  int     `json:"rating_2_count" gorm:"not null;default:0"`
+	Rating3Count  int     `json:"rating_3_count" gorm:"not null;default:0"`
+	Rating4Count  int     `json:"rating_4_count" gorm:"not null;default:0"`
+	Rating5Count  int     `json:"rating_5_count" gorm:"not null;default:0"`
+
 	// Relationships
 	Comments   []Comment `json:"comments" gorm:"foreignKey:BookID"`
 	AuthorData *Author   `json:"author_data,omitempty" gorm:"foreignKey:AuthorID"`
+	Work       *Work     `json:"work,omitempty" gorm:"foreignKey:WorkID"`
+	Series     *Series   `json:"series,omitempty" gorm:"foreignKey:SeriesID"`
+	Genres     []Genre   `json:"genres" gorm:"many2many:book_genres"`
+
+	// Authors, translators, editors, compilers and illustrators; the author at position 0
+	// mirrors AuthorID
+	Contributors []WorkContributor `json:"contributors,omitempty" gorm:"foreignKey:BookID"`
 }
@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// BookClub is a group of users reading one book at a time on a shared schedule
+type BookClub struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	OwnerID       uint       `json:"owner_id" gorm:"not null;index"`
+	Owner         *Admin     `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
+	Name          string     `json:"name" gorm:"type:varchar(100);not null"`
+	Description   string     `json:"description" gorm:"type:text"`
+	CurrentBookID *uint      `json:"current_book_id" gorm:"index"`
+	CurrentBook   *Book      `json:"current_book,omitempty" gorm:"foreignKey:CurrentBookID"`
+	StartsOn      *time.Time `json:"starts_on"` // First day of week 1 of the current book
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	MemberCount int64  `json:"member_count" gorm:"-"`
+	MyStatus    string `json:"my_status" gorm:"-"` // The viewer's membership status, "" when none
+}
+
+// Book club membership statuses. A membership is requested by the user or invited by the
+// owner and becomes accepted when the other side agrees, like a friend request.
+const (
+	ClubMemberRequested = "requested"
+	ClubMemberInvited   = "invited"
+	ClubMemberAccepted  = "accepted"
+)
+
+// BookClubMember is a user's membership, request or invitation in a club
+type BookClubMember struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	ClubID    uint       `json:"club_id" gorm:"not null;uniqueIndex:idx_book_club_member"`
+	Club      *BookClub  `json:"club,omitempty" gorm:"foreignKey:ClubID"`
+	AdminID   uint       `json:"admin_id" gorm:"not null;uniqueIndex:idx_book_club_member;index"`
+	Admin     *Admin     `json:"admin,omitempty" gorm:"foreignKey:AdminID"`
+	Status    string     `json:"status" gorm:"type:varchar(16);not null;index"`
+	InvitedBy *uint      `json:"invited_by"`
+	JoinedAt  *time.Time `json:"joined_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// BookClubSegment is one week of a club's reading schedule for a book
+type BookClubSegment struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ClubID    uint      `json:"club_id" gorm:"not null;uniqueIndex:idx_book_club_segment"`
+	BookID    uint      `json:"book_id" gorm:"not null;uniqueIndex:idx_book_club_segment"`
+	Week      int       `json:"week" gorm:"not null;uniqueIndex:idx_book_club_segment"`
+	StartPage int       `json:"start_page" gorm:"not null"`
+	EndPage   int       `json:"end_page" gorm:"not null"`
+	StartsOn  time.Time `json:"starts_on"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PostCount int64 `json:"post_count" gorm:"-"`
+}
+
+// BookClubPost is a message in the discussion thread of a schedule segment. Page is the
+// furthest page the post talks about; posts are hidden from members who have not read that far.
+type BookClubPost struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	ClubID    uint       `json:"club_id" gorm:"not null;index"`
+	SegmentID uint       `json:"segment_id" gorm:"not null;index"`
+	AdminID   uint       `json:"admin_id" gorm:"not null"`
+	Admin     *Admin     `json:"admin,omitempty" gorm:"foreignKey:AdminID"`
+	ParentID  *uint      `json:"parent_id" gorm:"index"`
+	Content   string     `json:"content" gorm:"type:text;not null"`
+	Page      *int       `json:"page"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+
+	SpoilerHidden bool `json:"spoiler_hidden" gorm:"-"`
+}
+
+// Book club activity types
+const (
+	ClubActivityJoined      = "joined"
+	ClubActivityLeft        = "left"
+	ClubActivityBookChanged = "book_changed"
+	ClubActivityPosted      = "posted"
+	ClubActivityFinished    = "finished"
+)
+
+// BookClubActivity is an entry in a club's members-only activity feed
+type BookClubActivity struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ClubID    uint      `json:"club_id" gorm:"not null;index:idx_book_club_activity"`
+	AdminID   uint      `json:"admin_id" gorm:"not null"`
+	Admin     *Admin    `json:"admin,omitempty" gorm:"foreignKey:AdminID"`
+	Type      string    `json:"type" gorm:"type:varchar(16);not null"`
+	BookID    *uint     `json:"book_id"`
+	SegmentID *uint     `json:"segment_id"`
+	PostID    *uint     `json:"post_id"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_book_club_activity"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Work is the abstract book that one or more editions (Book rows) are printings of
+type Work struct {
+	ID                 uint      `json:"id" gorm:"primaryKey"`
+	Title              string    `json:"title" gorm:"not null"`
+	AuthorID           *uint     `json:"author_id" gorm:"index"`
+	OriginalLanguage   string    `json:"original_language" gorm:"type:varchar(8)"`
+	FirstPublishedYear *int      `json:"first_published_year"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// Genre is a book category such as Roman or Şiir
+type Genre struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name" gorm:"type:varchar(64);not null"`
+	Slug string `json:"slug" gorm:"type:varchar(80);not null;uniqueIndex"`
+
+	BookCount int64 `json:"book_count,omitempty" gorm:"-"`
+}
+
+// Series groups books that are read in order
+type Series struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Name        string `json:"name" gorm:"type:varchar(255);not null"`
+	Slug        string `json:"slug" gorm:"type:varchar(255);not null;uniqueIndex"`
+	Description string `json:"description" gorm:"type:text"`
+
+	Books []Book `json:"books,omitempty" gorm:"foreignKey:SeriesID"`
+}
+
+// TableName keeps the plural of series readable
+func (Series) TableName() string {
+	return "series"
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Reading shelf statuses
+const (
+	ShelfWantToRead = "want_to_read"
+	ShelfReading    = "reading"
+	ShelfFinished   = "finished"
+	ShelfAbandoned  = "abandoned"
+)
+
+// ShelfStatuses are the built-in shelves, in display order
+var ShelfStatuses = []string{ShelfWantToRead, ShelfReading, ShelfFinished, ShelfAbandoned}
+
+// BookProgress is a user's reading state for one book. Status is the built-in shelf the
+// book is on; FinishedAt is set exactly when the status is finished.
+type BookProgress struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	AdminID         uint       `json:"admin_id" gorm:"not null;uniqueIndex:idx_book_progress_user_book"`
+	BookID          uint       `json:"book_id" gorm:"not null;uniqueIndex:idx_book_progress_user_book;index"`
+	Book            *Book      `json:"book,omitempty" gorm:"foreignKey:BookID"`
+	Status          string     `json:"status" gorm:"type:varchar(16);not null;default:reading;index"`
+	StatusChangedAt *time.Time `json:"status_changed_at"` // When the book was moved to its current shelf
+	CurrentPage     int        `json:"current_page" gorm:"not null;default:0"`
+	TotalPages      int        `json:"total_pages" gorm:"not null;default:0"` // Book.Page unless the user's edition differs
+	Percentage      float64    `json:"percentage" gorm:"not null;default:0"`
+	StartedAt       *time.Time `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ReadingSession is one logged sitting with a book
+type ReadingSession struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	AdminID         uint      `json:"admin_id" gorm:"not null;index:idx_reading_session_user_date"`
+	BookID          uint      `json:"book_id" gorm:"not null;index"`
+	StartPage       int       `json:"start_page"`
+	EndPage         int       `json:"end_page"`
+	PagesRead       int       `json:"pages_read" gorm:"not null;default:0"`
+	DurationMinutes int       `json:"duration_minutes" gorm:"not null;default:0"`
+	ReadAt          time.Time `json:"read_at" gorm:"not null;index:idx_reading_session_user_date"`
+	CreatedAt       time.Time `json:"created_at"`
+}
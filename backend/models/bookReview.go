@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// BookReview is a user's rating of a book with an optional written review.
+// Ratings go from 1 to 5 in half-star steps. Status uses the comment moderation
+// statuses and only affects the written part; the rating always counts.
+type BookReview struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	AdminID          uint       `json:"admin_id" gorm:"not null;uniqueIndex:idx_book_review_user_book"`
+	Admin            *Admin     `json:"admin,omitempty" gorm:"foreignKey:AdminID"`
+	BookID           uint       `json:"book_id" gorm:"not null;uniqueIndex:idx_book_review_user_book;index"`
+	Rating           float64    `json:"rating" gorm:"type:numeric(2,1);not null"`
+	Title            string     `json:"title" gorm:"type:varchar(150)"`
+	Body             string     `json:"body" gorm:"type:text"`
+	ContainsSpoilers bool       `json:"contains_spoilers" gorm:"default:false"`
+	Status           string     `json:"status" gorm:"type:varchar(16);not null;default:visible;index"`
+	EditedAt         *time.Time `json:"edited_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
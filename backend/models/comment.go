@@ -1,13 +1,40 @@
 package models
 
+import "time"
+
+// Comment moderation statuses
+const (
+	CommentStatusVisible = "visible"
+	CommentStatusPending = "pending" // Held by the content filter or reports until a moderator approves
+	CommentStatusHidden  = "hidden"  // Hidden by a moderator
+)
+
+// Comment belongs to exactly one target: a book (BookID) or a poem (PoemID).
+// Replies inherit the target of their parent.
 type Comment struct {
-	ID        uint   `json:"id" autoIncrement:"true"`
-	AdminID   uint   `json:"admin_id"`
-	Admin     Admin  `json:"admin" gorm:"foreignKey:AdminID"`
-	BookID    uint   `json:"book_id"`
-	Book      Book   `json:"book" gorm:"foreignKey:BookID"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	Page      *int   `json:"page"` // Optional page number
-	IsDeleted bool   `json:"is_deleted" gorm:"default:false"`
+	ID        uint       `json:"id" autoIncrement:"true"`
+	AdminID   uint       `json:"admin_id"`
+	Admin     Admin      `json:"admin" gorm:"foreignKey:AdminID"`
+	BookID    *uint      `json:"book_id"`
+	Book      *Book      `json:"book,omitempty" gorm:"foreignKey:BookID"`
+	PoemID    *uint      `json:"poem_id" gorm:"index"`
+	Poem      *Poem      `json:"poem,omitempty" gorm:"foreignKey:PoemID"`
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	Page      *int       `json:"page"` // Optional page number
+	IsDeleted bool       `json:"is_deleted" gorm:"default:false"`
+	Status    string     `json:"status" gorm:"type:varchar(16);not null;default:visible;index"`
+	CreatedAt *time.Time `json:"created_at"` // NULL for comments written before timestamps existed
+	EditedAt  *time.Time `json:"edited_at"`  // Set on every edit; NULL means never edited
+	EditCount int        `json:"edit_count" gorm:"default:0"`
+
+	// Threading: replies point at their parent and at the top-level comment of the thread
+	ParentID *uint `json:"parent_id" gorm:"index"`
+	RootID   *uint `json:"root_id" gorm:"index"`
+	Depth    int   `json:"depth" gorm:"default:0"`
+
+	// Filled in by the thread loader, not stored
+	Replies   []Comment              `json:"replies,omitempty" gorm:"-"`
+	Reactions []CommentReactionCount `json:"reactions,omitempty" gorm:"-"`
+	Mentions  []CommentMention       `json:"mentions,omitempty" gorm:"-"`
 }
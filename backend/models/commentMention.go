@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// CommentMention is an @username in a comment that resolved to a user.
+// Start and End are UTF-16 offsets of "@username" in the comment content.
+type CommentMention struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CommentID   uint      `json:"comment_id" gorm:"not null;uniqueIndex:idx_comment_mention_span"`
+	MentionedID uint      `json:"mentioned_id" gorm:"not null;index"`
+	Username    string    `json:"username"`
+	SpanStart   int       `json:"start" gorm:"not null;uniqueIndex:idx_comment_mention_span"`
+	SpanEnd     int       `json:"end" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
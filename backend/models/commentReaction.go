@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// CommentReaction is one user's emoji reaction on a comment
+type CommentReaction struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CommentID uint      `json:"comment_id" gorm:"not null;uniqueIndex:idx_comment_reaction"`
+	AdminID   uint      `json:"admin_id" gorm:"not null;uniqueIndex:idx_comment_reaction;index"`
+	Emoji     string    `json:"emoji" gorm:"type:varchar(16);not null;uniqueIndex:idx_comment_reaction"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CommentReactionCount summarizes one emoji on a comment for the viewer
+type CommentReactionCount struct {
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+	Reacted bool   `json:"reacted"` // Whether the viewer used this emoji
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// CommentRevision keeps the text a comment had before an edit
+type CommentRevision struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CommentID uint      `json:"comment_id" gorm:"not null;index"`
+	EditorID  uint      `json:"editor_id" gorm:"not null"` // Who replaced this version
+	Editor    Admin     `json:"editor" gorm:"foreignKey:EditorID"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"` // When this version was replaced
+}
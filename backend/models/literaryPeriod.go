@@ -0,0 +1,22 @@
+package models
+
+// Literary period kinds. A period is a broad era (Tanzimat); a movement is a group or school
+// within or across eras (Garip, İkinci Yeni).
+const (
+	LiteraryPeriodEra      = "period"
+	LiteraryPeriodMovement = "movement"
+)
+
+// LiteraryPeriod is an era or movement of Turkish literature that authors belong to
+type LiteraryPeriod struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Name        string `json:"name" gorm:"type:varchar(100);not null"`
+	Slug        string `json:"slug" gorm:"type:varchar(120);not null;uniqueIndex"`
+	Kind        string `json:"kind" gorm:"type:varchar(16);not null;default:period"`
+	StartYear   *int   `json:"start_year" gorm:"index"`
+	EndYear     *int   `json:"end_year"` // Nil while the period is ongoing
+	Description string `json:"description" gorm:"type:text"`
+
+	Authors     []Author `json:"authors,omitempty" gorm:"many2many:author_periods"`
+	AuthorCount int64    `json:"author_count,omitempty" gorm:"-"`
+}
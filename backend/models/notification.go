@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Notification types
+const (
+	NotificationAuthorPoem = "author_new_poem"
+	NotificationAuthorBook = "author_new_book"
+)
+
+// Notification is a stored notification shown in the user's inbox. The WebSocket event sent
+// with it is only delivered to connected clients; this row is what the user sees later.
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	AdminID   uint       `json:"admin_id" gorm:"not null;index:idx_notification_inbox"`
+	Type      string     `json:"type" gorm:"type:varchar(32);not null"`
+	Title     string     `json:"title"`
+	Slug      string     `json:"slug"`
+	AuthorID  *uint      `json:"author_id"`
+	PoemID    *uint      `json:"poem_id"`
+	BookID    *uint      `json:"book_id"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index:idx_notification_inbox"`
+
+	Author *Author `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// OnThisDayPost records a homepage item or Mihrimah card created by the daily anniversary
+// job, so the next run can replace it
+type OnThisDayPost struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Date      string    `json:"date" gorm:"type:varchar(10);not null;index"` // YYYY-MM-DD in Europe/Istanbul
+	Target    string    `json:"target" gorm:"type:varchar(16);not null"`     // homepage or mihrimah
+	ItemID    uint      `json:"item_id" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -12,7 +12,15 @@ type Poem struct {
 	CreatedAtParse string `json:"created_at_parse"`
 	Community      int    `json:"community" gorm:"default:1"` // 1=private (role_id 1,2), 2=public (role_id 3)
 	LikeCount      int    `json:"like_count" gorm:"-"`        // Computed field, not stored in DB
+	CommentCount   int    `json:"comment_count" gorm:"-"`     // Comments visible to the viewer, computed per request
+
+	// First publication of the poem, YYYY-MM-DD; used for anniversaries
+	FirstPublishedDate *string `json:"first_published_date" gorm:"type:varchar(10)"`
 
 	// Relationship
 	AuthorData *Author `json:"author_data,omitempty" gorm:"foreignKey:AuthorID"`
+	Tags       []Tag   `json:"tags,omitempty" gorm:"many2many:poem_tags"`
+
+	// Everyone credited on the poem by role; the first author is the one in AuthorID
+	Contributors []WorkContributor `json:"contributors,omitempty" gorm:"foreignKey:PoemID"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PoemOfTheDay stores the daily poem pick for a community level.
+// CommunityLevel 1 is the pool seen by role_id 1,2 (all poems), 2 is the public pool (role_id 3).
+type PoemOfTheDay struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	Date           string    `json:"date" gorm:"type:varchar(10);not null;uniqueIndex:idx_potd_date_level"` // YYYY-MM-DD in Europe/Istanbul
+	CommunityLevel int       `json:"community_level" gorm:"not null;uniqueIndex:idx_potd_date_level"`
+	PoemID         uint      `json:"poem_id" gorm:"not null;index"`
+	IsPinned       bool      `json:"is_pinned" gorm:"default:false"`
+	PinnedByID     *uint     `json:"pinned_by_id"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// Relationship
+	Poem Poem `json:"poem" gorm:"foreignKey:PoemID"`
+}
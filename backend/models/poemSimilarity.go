@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// PoemSimilarity is a precomputed item-item score between two poems.
+// Rows are rebuilt periodically by the recommendation worker.
+type PoemSimilarity struct {
+	PoemID        uint      `json:"poem_id" gorm:"primaryKey;autoIncrement:false"`
+	RelatedPoemID uint      `json:"related_poem_id" gorm:"primaryKey;autoIncrement:false"`
+	Score         float64   `json:"score"`
+	Support       int       `json:"support"` // Number of users who interacted with both poems
+	ComputedAt    time.Time `json:"computed_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PoemView is one deduplicated read of a poem: at most one row per viewer, poem and day.
+// No IP address or user agent is stored; anonymous viewers are identified by a salted hash.
+type PoemView struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	PoemID    uint      `json:"poem_id" gorm:"not null;uniqueIndex:idx_poem_view_dedupe;index"`
+	AdminID   uint      `json:"admin_id" gorm:"not null;default:0;index"` // 0 for anonymous sessions
+	ViewerKey string    `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_poem_view_dedupe"`
+	Day       string    `json:"day" gorm:"type:varchar(10);not null;uniqueIndex:idx_poem_view_dedupe;index"` // YYYY-MM-DD in the app timezone
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// ReadingGoal is a user's target for one calendar year. Either target may be 0 (not set).
+// The notified milestones remember the last progress notification sent, in percent.
+type ReadingGoal struct {
+	ID                    uint      `json:"id" gorm:"primaryKey"`
+	AdminID               uint      `json:"admin_id" gorm:"not null;uniqueIndex:idx_reading_goal_user_year"`
+	Year                  int       `json:"year" gorm:"not null;uniqueIndex:idx_reading_goal_user_year"`
+	BookTarget            int       `json:"book_target" gorm:"not null;default:0"`
+	PageTarget            int       `json:"page_target" gorm:"not null;default:0"`
+	NotifiedBookMilestone int       `json:"-" gorm:"not null;default:0"`
+	NotifiedPageMilestone int       `json:"-" gorm:"not null;default:0"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// Reading challenge metrics
+const (
+	ChallengeMetricBooks = "books"
+	ChallengeMetricPages = "pages"
+)
+
+// ReadingChallenge is a shared yearly target that the owner's friends can join
+type ReadingChallenge struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	OwnerID     uint      `json:"owner_id" gorm:"not null;index"`
+	Owner       *Admin    `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
+	Name        string    `json:"name" gorm:"type:varchar(100);not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	Year        int       `json:"year" gorm:"not null;index"`
+	Metric      string    `json:"metric" gorm:"type:varchar(8);not null"`
+	Target      int       `json:"target" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	MemberCount int64 `json:"member_count" gorm:"-"`
+	Joined      bool  `json:"joined" gorm:"-"`
+}
+
+// ReadingChallengeMember is a user taking part in a challenge
+type ReadingChallengeMember struct {
+	ChallengeID       uint      `json:"challenge_id" gorm:"primaryKey;autoIncrement:false"`
+	AdminID           uint      `json:"admin_id" gorm:"primaryKey;autoIncrement:false;index"`
+	NotifiedMilestone int       `json:"-" gorm:"not null;default:0"`
+	JoinedAt          time.Time `json:"joined_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Report target types
+const (
+	ReportTargetComment = "comment"
+	ReportTargetPoem    = "poem"
+	ReportTargetProfile = "profile"
+	ReportTargetReview  = "review"
+)
+
+// Report statuses
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"  // A moderator acted on the target
+	ReportStatusDismissed = "dismissed" // A moderator found nothing wrong
+)
+
+// Report is a user's complaint about a comment, poem or profile.
+// A user can report the same target only once.
+type Report struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	ReporterID   uint       `json:"reporter_id" gorm:"not null;uniqueIndex:idx_report_reporter_target"`
+	Reporter     Admin      `json:"reporter" gorm:"foreignKey:ReporterID"`
+	TargetType   string     `json:"target_type" gorm:"type:varchar(16);not null;uniqueIndex:idx_report_reporter_target;index:idx_report_target"`
+	TargetID     uint       `json:"target_id" gorm:"not null;uniqueIndex:idx_report_reporter_target;index:idx_report_target"`
+	Reason       string     `json:"reason" gorm:"type:varchar(32);not null"`
+	Details      string     `json:"details" gorm:"type:text"`
+	Status       string     `json:"status" gorm:"type:varchar(16);not null;default:open;index"`
+	ResolvedByID *uint      `json:"resolved_by_id"`
+	ResolvedAt   *time.Time `json:"resolved_at"`
+	Resolution   string     `json:"resolution"` // Moderation action taken
+	CreatedAt    time.Time  `json:"created_at"`
+}
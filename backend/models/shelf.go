@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Shelf is a custom, user-named book list. A book can be on several custom shelves
+// in addition to its built-in status shelf.
+type Shelf struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	AdminID   uint      `json:"admin_id" gorm:"not null;uniqueIndex:idx_shelf_user_slug"`
+	Name      string    `json:"name" gorm:"type:varchar(64);not null"`
+	Slug      string    `json:"slug" gorm:"type:varchar(80);not null;uniqueIndex:idx_shelf_user_slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	BookCount int64 `json:"book_count" gorm:"-"`
+}
+
+// ShelfBook puts a book on a custom shelf
+type ShelfBook struct {
+	ShelfID   uint      `json:"shelf_id" gorm:"primaryKey;autoIncrement:false"`
+	BookID    uint      `json:"book_id" gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Shelf move actions
+const (
+	ShelfMoveStatus = "status" // Built-in shelf changed
+	ShelfMoveAdd    = "add"    // Added to a custom shelf
+	ShelfMoveRemove = "remove" // Removed from a custom shelf
+)
+
+// ShelfMove records a book moving between a user's shelves
+type ShelfMove struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AdminID    uint      `json:"admin_id" gorm:"not null;index:idx_shelf_move_user_date"`
+	BookID     uint      `json:"book_id" gorm:"not null;index"`
+	Action     string    `json:"action" gorm:"type:varchar(8);not null"`
+	FromStatus string    `json:"from_status" gorm:"type:varchar(16)"` // "" when the book was on no shelf
+	ToStatus   string    `json:"to_status" gorm:"type:varchar(16)"`   // "" when the book was taken off its shelf
+	ShelfID    *uint     `json:"shelf_id"`                            // Custom shelf of add and remove moves
+	CreatedAt  time.Time `json:"created_at" gorm:"index:idx_shelf_move_user_date"`
+}
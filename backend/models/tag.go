@@ -0,0 +1,8 @@
+package models
+
+// Tag is a free-form label on poems such as aşk or sonbahar
+type Tag struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name" gorm:"type:varchar(64);not null"`
+	Slug string `json:"slug" gorm:"type:varchar(80);not null;uniqueIndex"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// AdminLikedPoem is the join model behind Admin.AdminLikedPoems
+type AdminLikedPoem struct {
+	AdminID   uint       `json:"admin_id" gorm:"primaryKey;autoIncrement:false"`
+	PoemID    uint       `json:"poem_id" gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt *time.Time `json:"created_at" gorm:"index"` // NULL only for rows created before timestamps existed
+}
+
+// AdminBookmarkPoem is the join model behind Admin.AdminBookmarkPoems
+type AdminBookmarkPoem struct {
+	AdminID   uint       `json:"admin_id" gorm:"primaryKey;autoIncrement:false"`
+	PoemID    uint       `json:"poem_id" gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt *time.Time `json:"created_at" gorm:"index"`
+}
+
+// UserBookRead is the join model behind Admin.UserBooksRead
+type UserBookRead struct {
+	AdminID   uint       `json:"admin_id" gorm:"primaryKey;autoIncrement:false"`
+	BookID    uint       `json:"book_id" gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt *time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName keeps the table created by the original many2many relation
+func (UserBookRead) TableName() string {
+	return "user_books_read"
+}
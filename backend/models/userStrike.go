@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// UserStrike is a moderator warning recorded against a user
+type UserStrike struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AdminID    uint      `json:"admin_id" gorm:"not null;index"` // The warned user
+	IssuedByID uint      `json:"issued_by_id" gorm:"not null"`
+	TargetType string    `json:"target_type" gorm:"type:varchar(16)"` // What the strike was for, see Report
+	TargetID   uint      `json:"target_id"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Contributor roles on a poem or book
+const (
+	ContributorRoleAuthor      = "author"
+	ContributorRoleTranslator  = "translator"
+	ContributorRoleEditor      = "editor"
+	ContributorRoleCompiler    = "compiler"
+	ContributorRoleIllustrator = "illustrator"
+)
+
+// ContributorRoles lists the roles in the order author pages group them
+var ContributorRoles = []string{
+	ContributorRoleAuthor,
+	ContributorRoleTranslator,
+	ContributorRoleEditor,
+	ContributorRoleCompiler,
+	ContributorRoleIllustrator,
+}
+
+// WorkContributor links an author to a poem or a book (exactly one of PoemID and BookID is
+// set) in a role. The contributor at position 0 with the author role mirrors the content's
+// AuthorID; the others are ordered by Position within their role.
+type WorkContributor struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	PoemID    *uint     `json:"poem_id,omitempty" gorm:"index"`
+	BookID    *uint     `json:"book_id,omitempty" gorm:"index"`
+	AuthorID  uint      `json:"author_id" gorm:"not null;index"`
+	Author    *Author   `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Role      string    `json:"role" gorm:"type:varchar(16);not null"`
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuthorWork is a poem or book listed on an author's page under one of their roles
+type AuthorWork struct {
+	Type     string `json:"type"` // poem or book
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	Position int    `json:"position"`
+}
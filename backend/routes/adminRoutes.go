@@ -25,6 +25,7 @@ func SetupAdminRoutes(app *fiber.App) {
 	// Lazy loading routes for profile stats
 	app.Get("/user-profile/:username/liked-poems", controllers.GetUserLikedPoems)
 	app.Get("/user-profile/:username/read-books", controllers.GetUserReadBooks)
+	app.Get("/user-profile/:username/shelves", controllers.GetUserShelves)
 	app.Get("/user-profile/:username/bookmarked-poems", controllers.GetUserBookmarkedPoems)
 	app.Get("/user-profile/:username/comments", controllers.GetUserComments)
 
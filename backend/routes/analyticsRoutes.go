@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupAnalyticsRoutes(app *fiber.App) {
+	app.Get("/analytics/poems", middlewares.IsAdmin, controllers.GetPoemAnalytics)
+	app.Get("/analytics/poems/:id", middlewares.IsAdmin, controllers.GetPoemAnalyticsById)
+	app.Get("/analytics/authors", middlewares.IsAdmin, controllers.GetAuthorAnalytics)
+	app.Get("/analytics/authors/:id", middlewares.IsAdmin, controllers.GetAuthorAnalyticsById)
+}
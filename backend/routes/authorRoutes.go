@@ -2,6 +2,7 @@ package routes
 
 import (
 	"backend/controllers"
+	"backend/middlewares"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -12,8 +13,19 @@ func SetupAuthorRoutes(app *fiber.App) {
 	app.Get("/get-all-authors-dropdown", controllers.GetAllAuthorsForDropdown)
 	app.Get("/get-author-by-id/:id", controllers.GetAuthorById)
 
+	// Following
+	app.Post("/follow-author/:id", controllers.FollowAuthor)
+	app.Delete("/unfollow-author/:id", controllers.UnfollowAuthor)
+	app.Get("/get-followed-authors", controllers.GetFollowedAuthors)
+	app.Get("/get-followed-authors-feed", controllers.GetFollowedAuthorsFeed)
+
 	// Admin routes (should be protected by middleware)
 	app.Post("/create-author", controllers.CreateAuthor)
 	app.Put("/update-author/:id", controllers.UpdateAuthor)
 	app.Delete("/delete-author/:id", controllers.DeleteAuthor)
+
+	// Legacy author strings and duplicate authors, admin only
+	app.Get("/legacy-authors", middlewares.IsAdmin, controllers.GetLegacyAuthorMatches)
+	app.Post("/legacy-authors/apply", middlewares.IsAdmin, controllers.ApplyLegacyAuthorMatches)
+	app.Post("/merge-authors", middlewares.IsAdmin, controllers.MergeAuthors)
 }
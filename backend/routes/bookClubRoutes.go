@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"backend/controllers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupBookClubRoutes(app *fiber.App) {
+	app.Post("/create-book-club", controllers.CreateBookClub)
+	app.Get("/get-book-clubs", controllers.GetBookClubs)
+	app.Get("/get-book-club/:id", controllers.GetBookClub)
+	app.Put("/update-book-club/:id", controllers.UpdateBookClub)
+	app.Delete("/delete-book-club/:id", controllers.DeleteBookClub)
+
+	app.Post("/join-book-club/:id", controllers.RequestBookClubMembership)
+	app.Post("/invite-to-book-club/:id", controllers.InviteToBookClub)
+	app.Get("/get-book-club-requests", controllers.GetBookClubRequests)
+	app.Post("/accept-book-club-member/:member_id", controllers.AcceptBookClubMember)
+	app.Delete("/delete-book-club-member/:member_id", controllers.DeleteBookClubMember)
+
+	app.Put("/set-book-club-book/:id", controllers.SetBookClubBook)
+	app.Get("/get-book-club-schedule/:id", controllers.GetBookClubSchedule)
+	app.Get("/get-book-club-activity/:id", controllers.GetBookClubActivity)
+	app.Post("/add-book-club-post/:segment_id", controllers.AddBookClubPost)
+	app.Get("/get-book-club-posts/:segment_id", controllers.GetBookClubPosts)
+	app.Delete("/delete-book-club-post/:id", controllers.DeleteBookClubPost)
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend/controllers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupBookReviewRoutes(app *fiber.App) {
+	app.Put("/set-book-review/:book_id", controllers.SetBookReview)
+	app.Delete("/delete-book-review/:book_id", controllers.DeleteBookReview)
+	app.Get("/get-book-reviews/:book_id", controllers.GetBookReviews)
+}
@@ -15,4 +15,8 @@ func SetupBooksRoutes(app *fiber.App) {
 	app.Get("/get-books-paginated", controllers.GetBooksPaginated)
 	app.Get("/get-book/:slug", controllers.GetBook)
 	app.Get("/get-book-by-id/:id", controllers.GetBookById)
+	app.Get("/get-book-editions/:id", controllers.GetBookEditions)
+	app.Get("/get-genres", controllers.GetGenres)
+	app.Get("/get-series", controllers.GetSeriesList)
+	app.Get("/get-series/:slug", controllers.GetSeries)
 }
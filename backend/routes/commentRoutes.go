@@ -2,12 +2,19 @@ package routes
 
 import (
 	"backend/controllers"
+	"backend/middlewares"
 	"github.com/gofiber/fiber/v2"
 )
 
 func SetupCommentsRoutes(app *fiber.App) {
 	app.Post("/add-comment", controllers.AddComment)
+	app.Put("/update-comment/:comment_id", controllers.UpdateComment)
 	app.Delete("/delete-comment/:comment_id", controllers.DeleteComment)
+	app.Get("/get-poem-comments/:poem_id", controllers.GetPoemComments)
+	app.Get("/get-comment-thread/:comment_id", controllers.GetCommentThread)
+	app.Post("/comment-reaction/:comment_id", controllers.ToggleCommentReaction)
+	app.Get("/get-comment-revisions/:comment_id", middlewares.IsAdmin, controllers.GetCommentRevisions)
+	app.Get("/export-notes", controllers.ExportNotes)
 	//app.Post("/undo-bookmark/:id", controllers.UndoBookmark)
 	//app.Get("/get-bookmark-id/:id", controllers.GetBookmarksIdByAdminId)
 	//app.Get("/get-bookmark/:id", controllers.GetBookmarksByAdminId)
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupImportRoutes(app *fiber.App) {
+	// Bulk import of books (CSV/JSON) and poems (Markdown), admin only
+	app.Post("/import/:kind", middlewares.IsAdmin, controllers.ImportContent)
+}
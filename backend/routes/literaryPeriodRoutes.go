@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupLiteraryPeriodRoutes(app *fiber.App) {
+	app.Get("/get-literary-periods", controllers.GetLiteraryPeriods)
+	app.Get("/get-literary-period/:slug", controllers.GetLiteraryPeriod)
+	app.Get("/get-literary-timeline", controllers.GetLiteraryTimeline)
+
+	// Admin routes
+	app.Post("/create-literary-period", middlewares.IsAdmin, controllers.CreateLiteraryPeriod)
+	app.Put("/update-literary-period/:id", middlewares.IsAdmin, controllers.UpdateLiteraryPeriod)
+	app.Delete("/delete-literary-period/:id", middlewares.IsAdmin, controllers.DeleteLiteraryPeriod)
+}
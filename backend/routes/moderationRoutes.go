@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupModerationRoutes(app *fiber.App) {
+	app.Post("/report", controllers.CreateReport)
+
+	// Admin-only moderation
+	app.Get("/moderation/queue", middlewares.IsAdmin, controllers.GetModerationQueue)
+	app.Post("/moderation/action", middlewares.IsAdmin, controllers.ModerateTarget)
+	app.Get("/moderation/strikes/:user_id", middlewares.IsAdmin, controllers.GetUserStrikes)
+}
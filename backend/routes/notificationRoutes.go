@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend/controllers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupNotificationRoutes(app *fiber.App) {
+	app.Get("/get-notifications", controllers.GetNotifications)
+	app.Put("/mark-notifications-read", controllers.MarkNotificationsRead)
+	app.Delete("/delete-notification/:id", controllers.DeleteNotification)
+}
@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"backend/controllers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupOnThisDayRoutes(app *fiber.App) {
+	app.Get("/on-this-day", controllers.GetOnThisDay)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPoemOfTheDayRoutes(app *fiber.App) {
+	app.Get("/poem-of-the-day", controllers.GetPoemOfTheDay)
+	app.Get("/poem-of-the-day/history", controllers.GetPoemOfTheDayHistory)
+
+	// Admin-only scheduling
+	app.Get("/poem-of-the-day/pins", middlewares.IsAdmin, controllers.GetPoemOfTheDayPins)
+	app.Post("/poem-of-the-day/pins", middlewares.IsAdmin, controllers.PinPoemOfTheDay)
+	app.Delete("/poem-of-the-day/pins/:id", middlewares.IsAdmin, controllers.UnpinPoemOfTheDay)
+}
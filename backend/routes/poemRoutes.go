@@ -17,4 +17,6 @@ func SetupPoemsRoutes(app *fiber.App) {
 	app.Get("/get-latest-poems", controllers.GetLatestPoems)
 	app.Get("/get-search-poems", controllers.GetSearchPoems)
 	app.Get("/get-popular-poems", controllers.GetPopularPoems)
+	app.Get("/get-trending-poems", controllers.GetTrendingPoems)
+	app.Get("/get-near-duplicate-poems", middlewares.IsAdmin, controllers.GetNearDuplicatePoems)
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"backend/controllers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupReadingGoalRoutes(app *fiber.App) {
+	app.Put("/set-reading-goal", controllers.SetReadingGoal)
+	app.Get("/get-reading-goal", controllers.GetReadingGoal)
+	app.Delete("/delete-reading-goal/:year", controllers.DeleteReadingGoal)
+
+	app.Post("/create-reading-challenge", controllers.CreateReadingChallenge)
+	app.Get("/get-reading-challenges", controllers.GetReadingChallenges)
+	app.Get("/get-reading-challenge/:id", controllers.GetReadingChallenge)
+	app.Post("/join-reading-challenge/:id", controllers.JoinReadingChallenge)
+	app.Delete("/leave-reading-challenge/:id", controllers.LeaveReadingChallenge)
+	app.Delete("/delete-reading-challenge/:id", controllers.DeleteReadingChallenge)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"backend/controllers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupReadingProgressRoutes(app *fiber.App) {
+	app.Put("/update-book-progress/:book_id", controllers.UpdateBookProgress)
+	app.Post("/add-reading-session/:book_id", controllers.AddReadingSession)
+	app.Delete("/delete-reading-session/:id", controllers.DeleteReadingSession)
+	app.Get("/get-book-progress/:book_id", controllers.GetBookProgress)
+	app.Get("/get-reading-progress", controllers.GetReadingProgress)
+	app.Get("/get-reading-stats", controllers.GetReadingStats)
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend/controllers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRecommendationRoutes(app *fiber.App) {
+	app.Get("/poems/:slug/related", controllers.GetRelatedPoems)
+	app.Get("/poems/:slug/similar", controllers.GetSimilarPoems)
+	app.Get("/recommendations", controllers.GetRecommendations)
+}
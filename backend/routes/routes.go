@@ -28,12 +28,25 @@ func Setup(app *fiber.App) {
 	SetupPoemsRoutes(app)
 	SetupBooksRoutes(app)
 	SetupBooksReadRoutes(app)
+	SetupReadingProgressRoutes(app)
+	SetupShelfRoutes(app)
+	SetupBookReviewRoutes(app)
+	SetupReadingGoalRoutes(app)
+	SetupBookClubRoutes(app)
 	SetupCommentsRoutes(app)
 	ReminderRoutes(app)
 	SetupHomepageRoutes(app)
 	SetupMihrimahCardRoutes(app)
 	SetupFriendshipRoutes(app)
 	SetupAuthorRoutes(app)
+	SetupLiteraryPeriodRoutes(app)
+	SetupNotificationRoutes(app)
+	SetupPoemOfTheDayRoutes(app)
+	SetupOnThisDayRoutes(app)
+	SetupRecommendationRoutes(app)
+	SetupAnalyticsRoutes(app)
+	SetupModerationRoutes(app)
+	SetupImportRoutes(app)
 
 }
 
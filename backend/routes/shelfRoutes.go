@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"backend/controllers"
+	"backend/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupShelfRoutes(app *fiber.App) {
+	app.Put("/set-book-shelf/:book_id", controllers.SetBookShelf)
+	app.Get("/get-shelves", controllers.GetShelves)
+	app.Post("/create-shelf", controllers.CreateShelf)
+	app.Put("/update-shelf/:id", controllers.UpdateShelf)
+	app.Delete("/delete-shelf/:id", controllers.DeleteShelf)
+	app.Post("/add-book-to-shelf/:id", controllers.AddBookToShelf)
+	app.Delete("/remove-book-from-shelf/:id/:book_id", controllers.RemoveBookFromShelf)
+	app.Get("/get-shelf-moves", controllers.GetShelfMoves)
+
+	// Goodreads library import and export
+	app.Post("/import-goodreads", middlewares.UploadRateLimiter(), controllers.ImportGoodreads)
+	app.Get("/export-goodreads", controllers.ExportGoodreads)
+}
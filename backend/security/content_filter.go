@@ -0,0 +1,131 @@
+package security
+
+import (
+	"backend/helpers"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// FilterAction is what should happen to user text after screening
+type FilterAction int
+
+const (
+	FilterAllow  FilterAction = iota // Publish as is
+	FilterHold                       // Publish only after a moderator approves
+	FilterReject                     // Refuse the text
+)
+
+// defaultHoldWords are held for review when CONTENT_FILTER_HOLD_WORDS is not set.
+// A trailing * matches any word starting with the term (Turkish suffixes). Terms are
+// compared after folding and collapsing repeated letters, so neither a term nor a prefix
+// may be a harmless word in that form: "piç" folds to "pic" and "shit*" would hold "shiitake".
+var defaultHoldWords = []string{
+	// Turkish
+	"amk", "aq", "orospu*", "siktir*", "sikerim", "yarrak*", "pezevenk*", "kahpe*", "ibne*", "gavat*", "şerefsiz*",
+	// English
+	"fuck*", "shit", "shits", "shitty", "bitch*", "cunt*", "asshole*", "bastard*", "motherfucker*",
+}
+
+// leetFold maps common character substitutions back to letters
+var leetFold = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's', '!': 'i',
+}
+
+// ContentFilter screens user text against configurable hold and reject word lists
+type ContentFilter struct {
+	reject []string
+	hold   []string
+}
+
+// FilterResult is the outcome of screening a text
+type FilterResult struct {
+	Action  FilterAction
+	Matches []string // Terms that matched, for the moderation queue
+}
+
+// NewContentFilter builds a filter from CONTENT_FILTER_REJECT_WORDS and CONTENT_FILTER_HOLD_WORDS
+// (comma separated). Without CONTENT_FILTER_HOLD_WORDS a built-in Turkish/English list is used.
+func NewContentFilter() *ContentFilter {
+	hold := defaultHoldWords
+	if value, ok := os.LookupEnv("CONTENT_FILTER_HOLD_WORDS"); ok {
+		hold = strings.Split(value, ",")
+	}
+	return &ContentFilter{
+		reject: normalizeTerms(strings.Split(os.Getenv("CONTENT_FILTER_REJECT_WORDS"), ",")),
+		hold:   normalizeTerms(hold),
+	}
+}
+
+// Check screens the text; reject terms win over hold terms
+func (f *ContentFilter) Check(text string) FilterResult {
+	words := normalizeWords(text)
+
+	if matches := matchTerms(words, f.reject); len(matches) > 0 {
+		return FilterResult{Action: FilterReject, Matches: matches}
+	}
+	if matches := matchTerms(words, f.hold); len(matches) > 0 {
+		return FilterResult{Action: FilterHold, Matches: matches}
+	}
+	return FilterResult{Action: FilterAllow}
+}
+
+// normalizeTerms folds configured terms the same way as the screened text
+func normalizeTerms(terms []string) []string {
+	normalized := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefix := strings.HasSuffix(strings.TrimSpace(term), "*")
+		folded := strings.Join(normalizeWords(term), "")
+		if folded == "" {
+			continue
+		}
+		if prefix {
+			folded += "*"
+		}
+		normalized = append(normalized, folded)
+	}
+	return normalized
+}
+
+// normalizeWords lowercases, folds Turkish letters and leetspeak, and splits into words.
+// Repeated letters are collapsed so "fuuuck" matches "fuck".
+func normalizeWords(text string) []string {
+	var folded strings.Builder
+	for _, char := range helpers.FoldTurkish(text) {
+		if letter, ok := leetFold[char]; ok {
+			char = letter
+		}
+		folded.WriteRune(char)
+	}
+
+	words := strings.FieldsFunc(folded.String(), func(char rune) bool {
+		return !unicode.IsLetter(char)
+	})
+	for i, word := range words {
+		var collapsed strings.Builder
+		var last rune
+		for _, char := range word {
+			if char != last {
+				collapsed.WriteRune(char)
+			}
+			last = char
+		}
+		words[i] = collapsed.String()
+	}
+	return words
+}
+
+// matchTerms returns the terms found among words
+func matchTerms(words []string, terms []string) []string {
+	var matches []string
+	for _, term := range terms {
+		prefix := strings.TrimSuffix(term, "*")
+		for _, word := range words {
+			if word == term || (prefix != term && strings.HasPrefix(word, prefix)) {
+				matches = append(matches, prefix)
+				break
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,87 @@
+package security
+
+import "testing"
+
+func newDefaultContentFilter() *ContentFilter {
+	return &ContentFilter{hold: normalizeTerms(defaultHoldWords)}
+}
+
+func TestContentFilterAllowsHarmlessWords(t *testing.T) {
+	filter := newDefaultContentFilter()
+	harmless := []string{
+		"pic", "pics", "picture", "pick", "picnic", "pickle", "epic", "Pictures of the sea",
+		"shiitake", "shitake", "shift", "Mississippi", "assessment", "classic", "passage",
+		"yararlı", "sikke", "ibadet", "kahve", "bastırmak", "amaç", "aqua",
+		"Kuşlar uçtu, şiir bitti.",
+	}
+	for _, text := range harmless {
+		t.Run(text, func(t *testing.T) {
+			result := filter.Check(text)
+			if result.Action != FilterAllow {
+				t.Errorf("Check(%q) = %v with matches %v, want allow", text, result.Action, result.Matches)
+			}
+		})
+	}
+}
+
+func TestContentFilterHoldsListedWords(t *testing.T) {
+	filter := newDefaultContentFilter()
+	tests := []struct {
+		text  string
+		match string
+	}{
+		{"orospular", "orospu"},
+		{"şerefsizler", "serefsiz"},
+		{"ŞEREFSİZ", "serefsiz"},
+		{"fuuuck", "fuck"},
+		{"fucking", "fuck"},
+		{"sh1t", "shit"},
+		{"this is shitty", "shity"},
+		{"$iktir git", "siktir"},
+		{"a$$hole", "ashole"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			result := filter.Check(tt.text)
+			if result.Action != FilterHold {
+				t.Fatalf("Check(%q) = %v, want hold", tt.text, result.Action)
+			}
+			if len(result.Matches) == 0 || result.Matches[0] != tt.match {
+				t.Errorf("Check(%q) matches = %v, want %q", tt.text, result.Matches, tt.match)
+			}
+		})
+	}
+}
+
+func TestContentFilterRejectWinsOverHold(t *testing.T) {
+	filter := &ContentFilter{
+		reject: normalizeTerms([]string{"spam*"}),
+		hold:   normalizeTerms([]string{"spam"}),
+	}
+	if result := filter.Check("spammer"); result.Action != FilterReject {
+		t.Errorf("Check(%q) = %v, want reject", "spammer", result.Action)
+	}
+}
+
+func TestNormalizeTerms(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"Şerefsiz*", "serefsiz*"},
+		{"yarrak*", "yarak*"},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		got := normalizeTerms([]string{tt.term})
+		if tt.want == "" {
+			if len(got) != 0 {
+				t.Errorf("normalizeTerms(%q) = %v, want none", tt.term, got)
+			}
+			continue
+		}
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("normalizeTerms(%q) = %v, want %q", tt.term, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package security
+
+import (
+	"bytes"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// richTextTags are the elements Quill produces, mapped to the element they are stored as.
+// Legacy synonyms (b, i, strike) are rewritten so equal formatting always renders the same.
+var richTextTags = map[string]string{
+	"p":          "p",
+	"br":         "br",
+	"strong":     "strong",
+	"b":          "strong",
+	"em":         "em",
+	"i":          "em",
+	"u":          "u",
+	"s":          "s",
+	"strike":     "s",
+	"del":        "s",
+	"ol":         "ol",
+	"ul":         "ul",
+	"li":         "li",
+	"h1":         "h1",
+	"h2":         "h2",
+	"h3":         "h3",
+	"blockquote": "blockquote",
+	"a":          "a",
+}
+
+// droppedTags are removed together with everything inside them
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"template": true, "noscript": true, "textarea": true, "select": true, "svg": true, "math": true,
+	"head": true, "title": true, "frame": true, "frameset": true, "applet": true,
+}
+
+// emptyRemovableTags are dropped when they end up without content
+var emptyRemovableTags = map[string]bool{
+	"strong": true, "em": true, "u": true, "s": true, "a": true,
+}
+
+// quillClassPattern matches Quill's alignment, indent and direction classes
+var quillClassPattern = regexp.MustCompile(`^ql-(align-(center|right|justify)|indent-[1-8]|direction-rtl)$`)
+
+// quillListTypes are the data-list values Quill 2 puts on list items
+var quillListTypes = map[string]bool{"bullet": true, "ordered": true, "checked": true, "unchecked": true}
+
+// safeLinkSchemes are the URL schemes kept on links
+var safeLinkSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// SanitizeRichText keeps only Quill formatting from HTML and returns it in a canonical form:
+// unknown wrappers are unwrapped, dangerous elements removed with their content, attributes
+// reduced to Quill classes and safe links, and the markup re-serialized with sorted attributes.
+func (s *Sanitizer) SanitizeRichText(input string) string {
+	nodes := parseHTMLFragment(input)
+
+	var out bytes.Buffer
+	for _, node := range nodes {
+		for _, clean := range sanitizeRichNode(node) {
+			html.Render(&out, clean)
+		}
+	}
+	return strings.TrimSpace(out.String())
+}
+
+// SanitizePlainText removes all markup and returns only the text, for fields rendered as plain text
+func (s *Sanitizer) SanitizePlainText(input string) string {
+	var out strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			out.WriteString(node.Data)
+			return
+		case html.ElementNode:
+			if droppedTags[node.Data] {
+				return
+			}
+			if node.DataAtom == atom.Br {
+				out.WriteString("\n")
+				return
+			}
+		case html.CommentNode:
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if node.Type == html.ElementNode && (node.DataAtom == atom.P || node.DataAtom == atom.Li || node.DataAtom == atom.Div) {
+			out.WriteString("\n")
+		}
+	}
+	for _, node := range parseHTMLFragment(input) {
+		walk(node)
+	}
+	return strings.TrimSpace(removeControlCharacters(out.String()))
+}
+
+// parseHTMLFragment parses input as the content of a <body> element
+func parseHTMLFragment(input string) []*html.Node {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(input), context)
+	if err != nil {
+		return []*html.Node{{Type: html.TextNode, Data: input}}
+	}
+	return nodes
+}
+
+// sanitizeRichNode returns the allowed replacement for node: itself cleaned, its cleaned
+// children when the element is not allowed, or nothing
+func sanitizeRichNode(node *html.Node) []*html.Node {
+	switch node.Type {
+	case html.TextNode:
+		return []*html.Node{{Type: html.TextNode, Data: node.Data}}
+	case html.ElementNode:
+		// handled below
+	default:
+		// Comments, doctypes and processing instructions are dropped
+		return nil
+	}
+
+	if droppedTags[node.Data] {
+		return nil
+	}
+
+	var children []*html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		children = append(children, sanitizeRichNode(child)...)
+	}
+
+	tag, allowed := richTextTags[node.Data]
+	if !allowed {
+		return children
+	}
+	if tag == "a" {
+		href := safeLinkHref(attr(node, "href"))
+		if href == "" {
+			return children
+		}
+		if len(children) == 0 {
+			return nil
+		}
+		clean := &html.Node{Type: html.ElementNode, Data: "a", DataAtom: atom.A, Attr: []html.Attribute{
+			{Key: "href", Val: href},
+			{Key: "rel", Val: "noopener noreferrer nofollow"},
+			{Key: "target", Val: "_blank"},
+		}}
+		appendChildren(clean, children)
+		return []*html.Node{clean}
+	}
+	if emptyRemovableTags[tag] && len(children) == 0 {
+		return nil
+	}
+
+	clean := &html.Node{Type: html.ElementNode, Data: tag, DataAtom: atom.Lookup([]byte(tag))}
+	if classes := quillClasses(attr(node, "class")); classes != "" {
+		clean.Attr = append(clean.Attr, html.Attribute{Key: "class", Val: classes})
+	}
+	if tag == "li" {
+		if listType := attr(node, "data-list"); quillListTypes[listType] {
+			clean.Attr = append(clean.Attr, html.Attribute{Key: "data-list", Val: listType})
+		}
+	}
+	if tag != "br" {
+		appendChildren(clean, children)
+	}
+	return []*html.Node{clean}
+}
+
+// appendChildren attaches detached nodes under parent
+func appendChildren(parent *html.Node, children []*html.Node) {
+	for _, child := range children {
+		parent.AppendChild(child)
+	}
+}
+
+// attr returns the value of the named attribute
+func attr(node *html.Node, key string) string {
+	for _, attribute := range node.Attr {
+		if attribute.Namespace == "" && attribute.Key == key {
+			return attribute.Val
+		}
+	}
+	return ""
+}
+
+// quillClasses keeps the Quill layout classes, deduplicated and sorted
+func quillClasses(value string) string {
+	seen := make(map[string]bool)
+	var classes []string
+	for _, class := range strings.Fields(value) {
+		if quillClassPattern.MatchString(class) && !seen[class] {
+			seen[class] = true
+			classes = append(classes, class)
+		}
+	}
+	sort.Strings(classes)
+	return strings.Join(classes, " ")
+}
+
+// safeLinkHref returns href if it is an absolute http(s) or mailto URL, otherwise ""
+func safeLinkHref(href string) string {
+	href = strings.TrimSpace(removeControlCharacters(href))
+	parsed, err := url.Parse(href)
+	if err != nil || !safeLinkSchemes[strings.ToLower(parsed.Scheme)] {
+		return ""
+	}
+	if parsed.Scheme != "mailto" && parsed.Host == "" {
+		return ""
+	}
+	return parsed.String()
+}
@@ -0,0 +1,78 @@
+package security
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeRichText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text", "Gül", "Gül"},
+		{"quill paragraph", `<p class="ql-align-center">Gül</p>`, `<p class="ql-align-center">Gül</p>`},
+		{"legacy synonyms", "<b>a</b><i>b</i><strike>c</strike>", "<strong>a</strong><em>b</em><s>c</s>"},
+		{"script removed with content", `<p>a<script>alert(1)</script>b</p>`, "<p>ab</p>"},
+		{"style removed with content", `<style>p{color:red}</style><p>a</p>`, "<p>a</p>"},
+		{"svg removed", `<svg onload="alert(1)"><circle/></svg>x`, "x"},
+		{"iframe removed", `<iframe src="https://example.com"></iframe>x`, "x"},
+		{"event handler dropped", `<p onclick="alert(1)">a</p>`, "<p>a</p>"},
+		{"img unwrapped", `<img src=x onerror="alert(1)">a`, "a"},
+		{"unknown wrapper unwrapped", `<div><span style="color:red">a</span></div>`, "a"},
+		{"foreign classes dropped", `<p class="evil ql-indent-2 ql-align-right ql-indent-2">a</p>`, `<p class="ql-align-right ql-indent-2">a</p>`},
+		{"javascript link unwrapped", `<a href="javascript:alert(1)">a</a>`, "a"},
+		{"obfuscated javascript link unwrapped", "<a href=\"java\tscript:alert(1)\">a</a>", "a"},
+		{"data link unwrapped", `<a href="data:text/html,<script>alert(1)</script>">a</a>`, "a"},
+		{"relative link unwrapped", `<a href="/admin">a</a>`, "a"},
+		{"https link kept", `<a href="https://example.com/x" onclick="x()">a</a>`, `<a href="https://example.com/x" rel="noopener noreferrer nofollow" target="_blank">a</a>`},
+		{"mailto link kept", `<a href="mailto:a@example.com">a</a>`, `<a href="mailto:a@example.com" rel="noopener noreferrer nofollow" target="_blank">a</a>`},
+		{"empty link dropped", `<a href="https://example.com"></a>`, ""},
+		{"empty formatting dropped", "<p><strong></strong>a</p>", "<p>a</p>"},
+		{"list type kept", `<ol><li data-list="bullet" data-evil="1">a</li></ol>`, `<ol><li data-list="bullet">a</li></ol>`},
+		{"comment dropped", "<!-- x -->a", "a"},
+		{"text escaped", "a < b & c", "a &lt; b &amp; c"},
+		{"escaped markup stays text", "&lt;script&gt;", "&lt;script&gt;"},
+	}
+	sanitizer := NewSanitizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizer.SanitizeRichText(tt.input)
+			if got != tt.want {
+				t.Errorf("SanitizeRichText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			// Sanitizing is idempotent, so the resanitize command only rewrites unsafe rows
+			if again := sanitizer.SanitizeRichText(got); again != got {
+				t.Errorf("SanitizeRichText is not idempotent: %q -> %q", got, again)
+			}
+			if strings.Contains(strings.ToLower(got), "<script") || strings.Contains(strings.ToLower(got), "javascript:") {
+				t.Errorf("SanitizeRichText(%q) = %q still contains script", tt.input, got)
+			}
+		})
+	}
+}
+
+func TestSanitizePlainText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text", "Merhaba", "Merhaba"},
+		{"tags removed", "<b>kalın</b> yazı", "kalın yazı"},
+		{"script content removed", "a<script>alert(1)</script>b", "ab"},
+		{"paragraphs become lines", "<p>bir</p><p>iki</p>", "bir\niki"},
+		{"br becomes newline", "bir<br>iki", "bir\niki"},
+		{"entities decoded", "a &amp; b", "a & b"},
+		{"control characters removed", "a\x00b\x07c", "abc"},
+	}
+	sanitizer := NewSanitizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizer.SanitizePlainText(tt.input); got != tt.want {
+				t.Errorf("SanitizePlainText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package security
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// languageCodePattern matches ISO 639-1 codes with an optional region (tr, en, pt-BR)
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+// NormalizeISBN removes spaces and hyphens and upper-cases the ISBN-10 check character
+func NormalizeISBN(isbn string) string {
+	isbn = strings.ToUpper(strings.TrimSpace(isbn))
+	return strings.NewReplacer("-", "", " ", "").Replace(isbn)
+}
+
+// NormalizeLanguageCode trims a language code and cases it as "tr" or "pt-BR"
+func NormalizeLanguageCode(code string) string {
+	language, region, found := strings.Cut(strings.TrimSpace(code), "-")
+	if !found {
+		return strings.ToLower(language)
+	}
+	return strings.ToLower(language) + "-" + strings.ToUpper(region)
+}
+
+// IsValidISBN10 checks the length, characters and mod-11 checksum of a normalized ISBN-10
+func IsValidISBN10(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+	sum := 0
+	for i, char := range isbn {
+		var digit int
+		switch {
+		case char >= '0' && char <= '9':
+			digit = int(char - '0')
+		case char == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += digit * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+// IsValidISBN13 checks the length, prefix and mod-10 checksum of a normalized ISBN-13
+func IsValidISBN13(isbn string) bool {
+	if len(isbn) != 13 || !(strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) {
+		return false
+	}
+	sum := 0
+	for i, char := range isbn {
+		if char < '0' || char > '9' {
+			return false
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(char-'0') * weight
+	}
+	return sum%10 == 0
+}
+
+// ISBN10To13 converts a valid ISBN-10 to its 978-prefixed ISBN-13
+func ISBN10To13(isbn string) string {
+	base := "978" + isbn[:9]
+	sum := 0
+	for i, char := range base {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(char-'0') * weight
+	}
+	return fmt.Sprintf("%s%d", base, (10-sum%10)%10)
+}
+
+// ValidateISBN normalizes an optional ISBN and checks that it is a valid ISBN-10 or ISBN-13
+// of the expected length (10 or 13). It returns the normalized value.
+func (v *Validator) ValidateISBN(field string, value string, length int) (string, error) {
+	isbn := NormalizeISBN(value)
+	if isbn == "" {
+		return "", nil
+	}
+	valid := (length == 10 && IsValidISBN10(isbn)) || (length == 13 && IsValidISBN13(isbn))
+	if !valid {
+		return "", &ValidationError{Field: field, Message: fmt.Sprintf("is not a valid ISBN-%d", length)}
+	}
+	return isbn, nil
+}
+
+// ValidateLanguageCode validates an optional ISO 639-1 language code such as "tr" or "en-US"
+func (v *Validator) ValidateLanguageCode(field string, value string) error {
+	if value == "" || languageCodePattern.MatchString(value) {
+		return nil
+	}
+	return &ValidationError{Field: field, Message: "must be an ISO 639-1 language code like tr or en"}
+}
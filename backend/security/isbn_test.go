@@ -0,0 +1,134 @@
+package security
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"978-605-09-0000-6", "9786050900006"},
+		{" 0 306 40615 2 ", "0306406152"},
+		{"0-8044-2957-x", "080442957X"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeISBN(tt.input); got != tt.want {
+			t.Errorf("NormalizeISBN(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeLanguageCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"TR", "tr"},
+		{" en ", "en"},
+		{"en-us", "en-US"},
+		{"PT-br", "pt-BR"},
+		{"", ""},
+	}
+	validator := NewValidator()
+	for _, tt := range tests {
+		got := NormalizeLanguageCode(tt.input)
+		if got != tt.want {
+			t.Errorf("NormalizeLanguageCode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+		if err := validator.ValidateLanguageCode("language", got); err != nil {
+			t.Errorf("ValidateLanguageCode(%q) error = %v", got, err)
+		}
+	}
+}
+
+func TestIsValidISBN10(t *testing.T) {
+	tests := []struct {
+		isbn string
+		want bool
+	}{
+		{"0306406152", true},
+		{"080442957X", true},  // X check character
+		{"0306406153", false}, // wrong check digit
+		{"X306406152", false}, // X only allowed as check character
+		{"030640615", false},  // too short
+		{"03064061522", false},
+		{"03064O6152", false}, // letter O
+	}
+	for _, tt := range tests {
+		if got := IsValidISBN10(tt.isbn); got != tt.want {
+			t.Errorf("IsValidISBN10(%q) = %v, want %v", tt.isbn, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidISBN13(t *testing.T) {
+	tests := []struct {
+		isbn string
+		want bool
+	}{
+		{"9780306406157", true},
+		{"9791090636071", true},  // 979 prefix
+		{"9780306406158", false}, // wrong check digit
+		{"9770306406157", false}, // not a book prefix
+		{"978030640615", false},
+		{"978030640615X", false},
+	}
+	for _, tt := range tests {
+		if got := IsValidISBN13(tt.isbn); got != tt.want {
+			t.Errorf("IsValidISBN13(%q) = %v, want %v", tt.isbn, got, tt.want)
+		}
+	}
+}
+
+func TestISBN10To13(t *testing.T) {
+	tests := []struct {
+		isbn10 string
+		want   string
+	}{
+		{"0306406152", "9780306406157"},
+		{"080442957X", "9780804429573"},
+		{"0140449132", "9780140449136"},
+	}
+	for _, tt := range tests {
+		got := ISBN10To13(tt.isbn10)
+		if got != tt.want {
+			t.Errorf("ISBN10To13(%q) = %q, want %q", tt.isbn10, got, tt.want)
+		}
+		if !IsValidISBN13(got) {
+			t.Errorf("ISBN10To13(%q) = %q is not a valid ISBN-13", tt.isbn10, got)
+		}
+	}
+}
+
+func TestValidateISBN(t *testing.T) {
+	tests := []struct {
+		value   string
+		length  int
+		want    string
+		wantErr bool
+	}{
+		{"", 13, "", false},
+		{"978-0-306-40615-7", 13, "9780306406157", false},
+		{"0-306-40615-2", 10, "0306406152", false},
+		{"0-306-40615-2", 13, "", true}, // ISBN-10 in the ISBN-13 field
+		{"978-0-306-40615-8", 13, "", true},
+	}
+	validator := NewValidator()
+	for _, tt := range tests {
+		got, err := validator.ValidateISBN("isbn", tt.value, tt.length)
+		if tt.wantErr {
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("ValidateISBN(%q, %d) error = %v, want a ValidationError", tt.value, tt.length, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ValidateISBN(%q, %d) = %q, %v, want %q", tt.value, tt.length, got, err, tt.want)
+		}
+	}
+}
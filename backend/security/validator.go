@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -206,3 +207,20 @@ func (v *Validator) ValidateID(field string, id uint) error {
 	}
 	return nil
 }
+
+// ValidateDate validates an optional calendar date (YYYY-MM-DD) that must not be in the
+// future. It returns the trimmed date, or nil when the value is empty.
+func (v *Validator) ValidateDate(field string, value *string) (*string, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, nil
+	}
+	date := strings.TrimSpace(*value)
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, &ValidationError{Field: field, Message: "must be a date in YYYY-MM-DD format"}
+	}
+	if parsed.After(time.Now()) {
+		return nil, &ValidationError{Field: field, Message: "must not be in the future"}
+	}
+	return &date, nil
+}